@@ -0,0 +1,267 @@
+package sparsemat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// alistReader reads the lines of an alist file as lists of integers.
+type alistReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// nextLine reads the next line, blank or not, and returns io.EOF at the end of the file.
+func (r *alistReader) nextLine(what string) ([]int, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	r.line++
+
+	fields := strings.Fields(r.scanner.Text())
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("alist line %v: invalid %v value %q", r.line, what, f)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// next reads the next non blank line.
+func (r *alistReader) next(what string) ([]int, error) {
+	for {
+		values, err := r.nextLine(what)
+		if err == io.EOF {
+			return nil, fmt.Errorf("alist: unexpected end of file reading %v", what)
+		}
+		if err != nil || len(values) > 0 {
+			return values, err
+		}
+	}
+}
+
+func (r *alistReader) nextN(what string, n int) ([]int, error) {
+	if n == 0 {
+		// an empty list is written as a blank line
+		return []int{}, nil
+	}
+	values, err := r.next(what)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) != n {
+		return nil, fmt.Errorf("alist line %v: expected %v %v values but found %v", r.line, n, what, len(values))
+	}
+	return values, nil
+}
+
+// readLists reads one line per node, each holding the 1-based indices of its
+// neighbors possibly padded with zeros, and checks them against the declared
+// degrees. Unpadded files write the nodes of degree 0 as blank lines, so a blank
+// line is the list of a node of degree 0, those at the end of the file may be
+// missing altogether. Blank lines before the list of any other node are skipped.
+func (r *alistReader) readLists(what string, degrees []int, maxDegree, neighbors int) ([][]int, error) {
+	lists := make([][]int, len(degrees))
+	for i := range lists {
+		var values []int
+		var err error
+		if degrees[i] == 0 {
+			values, err = r.nextLine(what + " list")
+			if err == io.EOF {
+				values, err = []int{}, nil
+			}
+		} else {
+			values, err = r.next(what + " list")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > maxDegree {
+			return nil, fmt.Errorf("alist line %v: %v %v has %v entries but the max degree is %v", r.line, what, i+1, len(values), maxDegree)
+		}
+
+		list := make([]int, 0, len(values))
+		for _, v := range values {
+			if v == 0 {
+				continue
+			}
+			if v < 0 || v > neighbors {
+				return nil, fmt.Errorf("alist line %v: %v %v has index %v out of range [1-%v]", r.line, what, i+1, v, neighbors)
+			}
+			list = append(list, v-1)
+		}
+		if len(list) != degrees[i] {
+			return nil, fmt.Errorf("alist line %v: %v %v has %v entries but its degree is %v", r.line, what, i+1, len(list), degrees[i])
+		}
+
+		sort.Ints(list)
+		for k := 1; k < len(list); k++ {
+			if list[k] == list[k-1] {
+				return nil, fmt.Errorf("alist line %v: %v %v has index %v more than once", r.line, what, i+1, list[k]+1)
+			}
+		}
+		lists[i] = list
+	}
+	return lists, nil
+}
+
+// ReadAlist reads a parity-check matrix in the alist format used by MacKay's
+// encyclopedia of sparse graph codes. The file starts with the number of columns
+// and rows, the max column and row degrees and the degree of every column and row,
+// followed by the 1-based row indices of each column and the 1-based column indices
+// of each row, where lists may be padded with zeros. The degree lists and both
+// index lists are checked against each other and an error describing the first
+// problem found is returned for malformed files.
+func ReadAlist(reader io.Reader) (SparseMat, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	r := &alistReader{scanner: scanner}
+
+	dims, err := r.nextN("dimension", 2)
+	if err != nil {
+		return nil, err
+	}
+	cols, rows := dims[0], dims[1]
+	if cols < 0 || rows < 0 {
+		return nil, fmt.Errorf("alist line %v: invalid dimensions %v %v", r.line, cols, rows)
+	}
+
+	maxDegrees, err := r.nextN("max degree", 2)
+	if err != nil {
+		return nil, err
+	}
+	colDegrees, err := r.nextN("column degree", cols)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDegrees(r.line, "column", colDegrees, maxDegrees[0], rows); err != nil {
+		return nil, err
+	}
+	rowDegrees, err := r.nextN("row degree", rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDegrees(r.line, "row", rowDegrees, maxDegrees[1], cols); err != nil {
+		return nil, err
+	}
+
+	colLists, err := r.readLists("column", colDegrees, maxDegrees[0], rows)
+	if err != nil {
+		return nil, err
+	}
+	rowLists, err := r.readLists("row", rowDegrees, maxDegrees[1], cols)
+	if err != nil {
+		return nil, err
+	}
+
+	// every column entry must show up in the matching row list
+	for j, list := range colLists {
+		for _, i := range list {
+			row := rowLists[i]
+			x := findIndex(row, j)
+			if x == len(row) || row[x] != j {
+				return nil, fmt.Errorf("alist: column %v lists row %v but row %v does not list column %v", j+1, i+1, i+1, j+1)
+			}
+		}
+	}
+	total := 0
+	for j := range colLists {
+		total += len(colLists[j])
+	}
+	for i := range rowLists {
+		total -= len(rowLists[i])
+	}
+	if total != 0 {
+		return nil, fmt.Errorf("alist: row lists and column lists have a different number of entries")
+	}
+
+	if values, err := r.next("trailing"); err == nil {
+		return nil, fmt.Errorf("alist line %v: unexpected data %v after the row lists", r.line, values)
+	} else if scanner.Err() != nil {
+		return nil, scanner.Err()
+	}
+
+	return csrMatFromIndices(rows, cols, rowLists), nil
+}
+
+// checkDegrees checks the degrees are valid and at most the declared max degree,
+// which may be larger than the actual max as it only sets the padding width.
+func checkDegrees(line int, what string, degrees []int, maxDegree, limit int) error {
+	for i, d := range degrees {
+		if d < 0 || d > limit {
+			return fmt.Errorf("alist line %v: %v %v has invalid degree %v", line, what, i+1, d)
+		}
+		if d > maxDegree {
+			return fmt.Errorf("alist line %v: %v %v has degree %v above the declared max %v", line, what, i+1, d, maxDegree)
+		}
+	}
+	return nil
+}
+
+// WriteAlist writes m in the alist format, padding the index lists with zeros
+// to the max degree as done in MacKay's encyclopedia of sparse graph codes.
+func WriteAlist(writer io.Writer, m SparseMat) error {
+	rows, cols := m.Dims()
+
+	rowLists := make([][]int, rows)
+	colLists := make([][]int, cols)
+	maxRow, maxCol := 0, 0
+	for i := 0; i < rows; i++ {
+		rowLists[i] = m.Row(i).NonzeroArray()
+		for _, j := range rowLists[i] {
+			colLists[j] = append(colLists[j], i)
+		}
+		if len(rowLists[i]) > maxRow {
+			maxRow = len(rowLists[i])
+		}
+	}
+	for j := 0; j < cols; j++ {
+		if len(colLists[j]) > maxCol {
+			maxCol = len(colLists[j])
+		}
+	}
+
+	w := bufio.NewWriter(writer)
+	fmt.Fprintf(w, "%v %v\n%v %v\n", cols, rows, maxCol, maxRow)
+	writeDegrees(w, colLists)
+	writeDegrees(w, rowLists)
+	writeLists(w, colLists, maxCol)
+	writeLists(w, rowLists, maxRow)
+	return w.Flush()
+}
+
+func writeDegrees(w *bufio.Writer, lists [][]int) {
+	for i, list := range lists {
+		if i > 0 {
+			w.WriteByte(' ')
+		}
+		w.WriteString(strconv.Itoa(len(list)))
+	}
+	w.WriteByte('\n')
+}
+
+func writeLists(w *bufio.Writer, lists [][]int, maxDegree int) {
+	for _, list := range lists {
+		for k := 0; k < maxDegree; k++ {
+			if k > 0 {
+				w.WriteByte(' ')
+			}
+			if k < len(list) {
+				w.WriteString(strconv.Itoa(list[k] + 1))
+			} else {
+				w.WriteByte('0')
+			}
+		}
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,116 @@
+package sparsemat
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const hammingAlist = `7 3
+3 4
+1 1 2 1 2 2 3
+4 4 4
+1 0 0
+2 0 0
+1 2 0
+3 0 0
+1 3 0
+2 3 0
+1 2 3
+1 3 5 7
+2 3 6 7
+4 5 6 7
+`
+
+func TestReadAlist(t *testing.T) {
+	_, hh := HammingCode(3)
+	tests := []struct {
+		input    string
+		expected SparseMat
+	}{
+		{hammingAlist, hh},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 2\n3\n", CSRMat(2, 3, 1, 1, 0, 0, 0, 1)},
+		{"\n3 2\n\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 2\n3 0\n\n", CSRMat(2, 3, 1, 1, 0, 0, 0, 1)},
+		{"2 2\n0 0\n0 0\n0 0\n", CSRMat(2, 2)},
+		// unpadded with an empty column and an empty row written as blank lines
+		{"3 3\n1 2\n1 1 0\n2 0 0\n1\n1\n\n1 2\n\n\n", CSRMat(3, 3, 1, 1, 0, 0, 0, 0, 0, 0, 0)},
+		{"3 3\n1 2\n1 1 0\n2 0 0\n1\n1\n\n1 2\n", CSRMat(3, 3, 1, 1, 0, 0, 0, 0, 0, 0, 0)},
+		{"3 2\n2 2\n1 0 2\n2 1\n1\n\n1 2\n1 3\n3\n", CSRMat(2, 3, 1, 0, 1, 0, 0, 1)},
+		// blank separator lines around the lists
+		{"3 2\n1 2\n1 1 1\n2 1\n\n1\n1\n2\n\n1 2\n3\n", CSRMat(2, 3, 1, 1, 0, 0, 0, 1)},
+		// declared max degrees larger than the actual ones
+		{"3 2\n2 3\n1 1 1\n2 1\n1 0\n1 0\n2 0\n1 2 0\n3 0 0\n", CSRMat(2, 3, 1, 1, 0, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, err := ReadAlist(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadAlist_malformed(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "unexpected end of file"},
+		{"3 x\n", "invalid dimension value"},
+		{"3 2\n1 2\n1 1\n", "expected 3 column degree values"},
+		{"3 2\n1 1\n1 1 1\n2 1\n", "row 1 has degree 2 above the declared max 1"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 2\n", "unexpected end of file"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 3\n3\n", "column 2 lists row 1 but row 1 does not list column 2"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n3\n1 2\n3\n", "out of range"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 1\n3\n", "more than once"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1\n3\n", "row 1 has 1 entries but its degree is 2"},
+		{"3 2\n1 2\n1 1 1\n2 1\n1\n1\n2\n1 2\n3\n4\n", "unexpected data"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := ReadAlist(strings.NewReader(test.input))
+			if err == nil {
+				t.Fatalf("expected error containing %q", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q but found %q", test.err, err)
+			}
+		})
+	}
+}
+
+func TestWriteAlist(t *testing.T) {
+	_, hh := HammingCode(3)
+	tests := []struct {
+		m SparseMat
+	}{
+		{hh},
+		{DOKMat(2, 3, 1, 1, 0, 0, 0, 1)},
+		{CSRMat(2, 2)},
+		{randomLDPC(20, 40, 3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := WriteAlist(buff, test.m); err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 && buff.String() != hammingAlist {
+				t.Fatalf("expected \n%v\n but found \n%v\n", hammingAlist, buff.String())
+			}
+
+			actual, err := ReadAlist(buff)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.m) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.m, actual)
+			}
+		})
+	}
+}
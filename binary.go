@@ -0,0 +1,172 @@
+package sparsemat
+
+import (
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+)
+
+// binaryVersion is the version of the binary layout written by MarshalBinary.
+//
+// The layout starts with the version byte and a byte identifying the storage
+// type. Matrices follow with the number of rows and cols and then every row,
+// vectors follow with their length and then their indices. A list of indices is
+// written as its count followed by the gap before each index, so sorted indices
+// i0 < i1 < ... are written as i0, i1-i0-1, ... All values are unsigned varints.
+const binaryVersion = 1
+
+const (
+	binaryCSRMatrix byte = iota + 1
+	binaryDOKMatrix
+	binaryCSRVector
+	binaryDOKVector
+)
+
+// The binary methods are left out of the SparseMat and SparseVector interfaces on
+// purpose, gob treats interface types implementing encoding.BinaryMarshaler as
+// opaque values and would no longer record the concrete type of interface values.
+func init() {
+	// allow SparseMat and SparseVector interface values to be gob encoded
+	gob.Register(&CSRMatrix{})
+	gob.Register(&DOKMatrix{})
+	gob.Register(&CSRVector{})
+	gob.Register(&DOKVector{})
+}
+
+func appendUvarint(buf []byte, v int) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], uint64(v))
+	return append(buf, tmp[:n]...)
+}
+
+func appendIndices(buf []byte, indices []int) []byte {
+	buf = appendUvarint(buf, len(indices))
+	prev := -1
+	for _, i := range indices {
+		buf = appendUvarint(buf, i-prev-1)
+		prev = i
+	}
+	return buf
+}
+
+func marshalMatrix(kind byte, m SparseMat) []byte {
+	rows, cols := m.Dims()
+	buf := []byte{binaryVersion, kind}
+	buf = appendUvarint(buf, rows)
+	buf = appendUvarint(buf, cols)
+	for i := 0; i < rows; i++ {
+		buf = appendIndices(buf, m.Row(i).NonzeroArray())
+	}
+	return buf
+}
+
+func marshalVector(kind byte, v SparseVector) []byte {
+	buf := []byte{binaryVersion, kind}
+	buf = appendUvarint(buf, v.Len())
+	return appendIndices(buf, v.NonzeroArray())
+}
+
+// binaryDecoder reads the values written by the append functions.
+type binaryDecoder struct {
+	data []byte
+	off  int
+}
+
+func (d *binaryDecoder) header(kinds ...byte) error {
+	if len(d.data) < 2 {
+		return fmt.Errorf("binary data too short")
+	}
+	if d.data[0] != binaryVersion {
+		return fmt.Errorf("unsupported binary version %v", d.data[0])
+	}
+	for _, k := range kinds {
+		if d.data[1] == k {
+			d.off = 2
+			return nil
+		}
+	}
+	return fmt.Errorf("binary storage type %v does not match expected %v", d.data[1], kinds)
+}
+
+// uvarint reads a value that can be no larger than limit.
+func (d *binaryDecoder) uvarint(limit int) (int, error) {
+	v, n := binary.Uvarint(d.data[d.off:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid varint at offset %v", d.off)
+	}
+	if limit < 0 || v > uint64(limit) {
+		return 0, fmt.Errorf("value %v at offset %v is larger than %v", v, d.off, limit)
+	}
+	d.off += n
+	return int(v), nil
+}
+
+func (d *binaryDecoder) remaining() int {
+	return len(d.data) - d.off
+}
+
+// indices reads a list of sorted indices which must be less than length.
+func (d *binaryDecoder) indices(length int) ([]int, error) {
+	count, err := d.uvarint(d.remaining())
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make([]int, count)
+	prev := -1
+	for k := range indices {
+		gap, err := d.uvarint(length - prev - 2)
+		if err != nil {
+			return nil, fmt.Errorf("index out of range: %v", err)
+		}
+		prev += gap + 1
+		indices[k] = prev
+	}
+	return indices, nil
+}
+
+func (d *binaryDecoder) done() error {
+	if d.off != len(d.data) {
+		return fmt.Errorf("%v unexpected trailing bytes", len(d.data)-d.off)
+	}
+	return nil
+}
+
+func unmarshalMatrix(data []byte) (rows, cols int, indices [][]int, err error) {
+	d := &binaryDecoder{data: data}
+	if err = d.header(binaryCSRMatrix, binaryDOKMatrix); err != nil {
+		return
+	}
+
+	// every row takes at least one byte
+	if rows, err = d.uvarint(d.remaining()); err != nil {
+		return
+	}
+	if cols, err = d.uvarint(int(^uint(0) >> 1)); err != nil {
+		return
+	}
+	indices = make([][]int, rows)
+	for i := range indices {
+		if indices[i], err = d.indices(cols); err != nil {
+			return
+		}
+	}
+	err = d.done()
+	return
+}
+
+func unmarshalVector(data []byte) (length int, indices []int, err error) {
+	d := &binaryDecoder{data: data}
+	if err = d.header(binaryCSRVector, binaryDOKVector); err != nil {
+		return
+	}
+
+	if length, err = d.uvarint(int(^uint(0) >> 1)); err != nil {
+		return
+	}
+	if indices, err = d.indices(length); err != nil {
+		return
+	}
+	err = d.done()
+	return
+}
@@ -0,0 +1,140 @@
+package sparsemat
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"strconv"
+	"testing"
+)
+
+func TestMatrix_Binary(t *testing.T) {
+	tests := []struct {
+		m, result SparseMat
+	}{
+		{CSRIdentity(5), &CSRMatrix{}},
+		{CSRMat(3, 4, 0, 1, 1, 0, 0, 0, 0, 0, 1, 0, 0, 1), &CSRMatrix{}},
+		{DOKMat(3, 4, 0, 1, 1, 0, 0, 0, 0, 0, 1, 0, 0, 1), &DOKMatrix{}},
+		{CSRMat(2, 0), &CSRMatrix{}},
+		{randomMatrix(50, 300), &CSRMatrix{}},
+		{randomMatrix(50, 300), &DOKMatrix{}},
+		{DOKMatCopy(randomMatrix(30, 30)), &CSRMatrix{}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			data, err := test.m.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = test.result.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if !test.result.Equals(test.m) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.m, test.result)
+			}
+		})
+	}
+}
+
+func TestVector_Binary(t *testing.T) {
+	tests := []struct {
+		v, result SparseVector
+	}{
+		{CSRVec(5, 1, 0, 0, 1, 1), &CSRVector{}},
+		{DOKVec(5, 1, 0, 0, 1, 1), &DOKVector{}},
+		{DOKVec(5, 1, 0, 0, 1, 1), &CSRVector{}},
+		{CSRVec(0), &DOKVector{}},
+		{randomCSRVector(1000), &CSRVector{}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			data, err := test.v.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = test.result.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if !test.result.Equals(test.v) {
+				t.Fatalf("expected %v but found %v", test.v, test.result)
+			}
+		})
+	}
+}
+
+func TestBinary_layout(t *testing.T) {
+	data, _ := CSRVec(300).Set(2, 1).Set(3, 1).Set(299, 1).(*CSRVector).MarshalBinary()
+	expected := []byte{binaryVersion, binaryCSRVector, 0xac, 0x02, 3, 2, 0, 0xa7, 0x02}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("expected %v but found %v", expected, data)
+	}
+}
+
+func TestBinary_malformed(t *testing.T) {
+	vec, _ := CSRVec(3, 0, 1, 1).(*CSRVector).MarshalBinary()
+	mat, _ := CSRIdentity(3).(*CSRMatrix).MarshalBinary()
+	tests := []struct {
+		data   []byte
+		target encoding.BinaryUnmarshaler
+	}{
+		{[]byte{}, &CSRVector{}},
+		{[]byte{2, binaryCSRVector, 3, 0}, &CSRVector{}},
+		{vec, &CSRMatrix{}},
+		{mat, &DOKVector{}},
+		{append(vec, 0), &CSRVector{}},
+		{vec[:len(vec)-1], &DOKVector{}},
+		{[]byte{binaryVersion, binaryCSRVector, 3, 1, 3}, &CSRVector{}},
+		{[]byte{binaryVersion, binaryCSRVector, 0, 1, 0}, &CSRVector{}},
+		{[]byte{binaryVersion, binaryDOKMatrix, 1, 2, 2, 1, 0}, &DOKMatrix{}},
+		{[]byte{binaryVersion, binaryCSRMatrix, 100, 2}, &CSRMatrix{}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if err := test.target.UnmarshalBinary(test.data); err == nil {
+				t.Fatalf("expected an error decoding %v", test.data)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_UnmarshalBinaryWide(t *testing.T) {
+	// 0 rows and 2^49 cols, the columns must not be allocated up front
+	data := []byte{binaryVersion, binaryDOKMatrix, 0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}
+
+	var mat DOKMatrix
+	if err := mat.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if rows, cols := mat.Dims(); rows != 0 || cols != 1<<49 {
+		t.Fatalf("expected (0,%v) but found (%v,%v)", 1<<49, rows, cols)
+	}
+}
+
+func TestGob(t *testing.T) {
+	type payload struct {
+		H    SparseMat
+		D    *DOKMatrix
+		Word SparseVector
+	}
+	expected := payload{
+		H:    CSRMat(2, 3, 1, 1, 0, 0, 1, 1),
+		D:    dokMat(2, 2, 0, 1, 1, 1),
+		Word: DOKVec(3, 0, 1, 1),
+	}
+
+	buff := &bytes.Buffer{}
+	if err := gob.NewEncoder(buff).Encode(expected); err != nil {
+		t.Fatal(err)
+	}
+	var actual payload
+	if err := gob.NewDecoder(buff).Decode(&actual); err != nil {
+		t.Fatal(err)
+	}
+
+	if !actual.H.Equals(expected.H) || !actual.D.Equals(expected.D) || !actual.Word.Equals(expected.Word) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+	if _, ok := actual.Word.(*DOKVector); !ok {
+		t.Fatalf("expected the vector type to be kept")
+	}
+}
@@ -0,0 +1,382 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CirculantMatrix is a square matrix where every row is the row above it rotated
+// right by one, so m[i][j] = row[(j-i) mod n]. Only the first row is stored.
+// The circulant matrices of size n add and multiply like the polynomials of their
+// first rows modulo x^n + 1.
+//
+// Updates in place, like Set or PermuteRows, panic unless the result is still
+// circulant, for example when the rows are rotated. Updates changing the shape
+// always panic.
+type CirculantMatrix struct {
+	size int
+	row  *CSRVector
+}
+
+type circulantMatrix struct {
+	Size int
+	Row  []int
+}
+
+func (mat *CirculantMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(circulantMatrix{
+		Size: mat.size,
+		Row:  mat.row.indices,
+	})
+}
+
+func (mat *CirculantMatrix) UnmarshalJSON(bytes []byte) error {
+	var m circulantMatrix
+	err := json.Unmarshal(bytes, &m)
+	if err != nil {
+		return err
+	}
+
+	if m.Size <= 0 {
+		return fmt.Errorf("invalid circulant size %v", m.Size)
+	}
+	if m.Row == nil {
+		m.Row = make([]int, 0)
+	}
+	if err = checkIndices(m.Row, m.Size); err != nil {
+		return fmt.Errorf("row %v", err)
+	}
+
+	mat.size = m.Size
+	mat.row = &CSRVector{length: m.Size, indices: m.Row}
+	return nil
+}
+
+// CirculantMat creates a new circulant matrix with the given first row, its size
+// is the length of the row.
+func CirculantMat(row SparseVector) *CirculantMatrix {
+	if row.Len() <= 0 {
+		panic(fmt.Sprintf("circulant size must be > 0 found %v", row.Len()))
+	}
+	return &CirculantMatrix{
+		size: row.Len(),
+		row:  &CSRVector{length: row.Len(), indices: row.NonzeroArray()},
+	}
+}
+
+// Poly returns the polynomial of the first row, index i holding the coefficient of x^i.
+func (mat *CirculantMatrix) Poly() Poly {
+	return PolyFromVec(mat.row)
+}
+
+// FirstRow returns a copy of the first row.
+func (mat *CirculantMatrix) FirstRow() SparseVector {
+	return CSRVecCopy(mat.row)
+}
+
+func (mat *CirculantMatrix) checkRowBounds(i int) {
+	if i < 0 || i >= mat.size {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.size-1))
+	}
+}
+
+// Dims returns the dimensions of the matrix.
+func (mat *CirculantMatrix) Dims() (int, int) {
+	return mat.size, mat.size
+}
+
+// At returns the value at row index i and column index j.
+func (mat *CirculantMatrix) At(i, j int) int {
+	mat.checkRowBounds(i)
+	mat.checkRowBounds(j)
+
+	return mat.row.at(((j-i)%mat.size + mat.size) % mat.size)
+}
+
+// Row returns row i, the first row rotated right by i.
+func (mat *CirculantMatrix) Row(i int) SparseVector {
+	mat.checkRowBounds(i)
+
+	return &CSRVector{length: mat.size, indices: rotateIndices(mat.row.indices, i, mat.size)}
+}
+
+// Column returns column j, which is the first column rotated down by j.
+func (mat *CirculantMatrix) Column(j int) SparseVector {
+	mat.checkRowBounds(j)
+
+	// m[i][j] is set for i = j-k with row[k] set
+	first := make([]int, 0, len(mat.row.indices))
+	for k := len(mat.row.indices) - 1; k >= 0; k-- {
+		first = append(first, (mat.size-mat.row.indices[k])%mat.size)
+	}
+	if len(first) > 0 && first[len(first)-1] == 0 {
+		first = append([]int{0}, first[:len(first)-1]...)
+	}
+	return &CSRVector{length: mat.size, indices: rotateIndices(first, j, mat.size)}
+}
+
+// T returns a new circulant matrix that is the transpose of this matrix.
+func (mat *CirculantMatrix) T() SparseMat {
+	return CirculantMat(mat.Column(0))
+}
+
+// MulVec returns the product of this matrix with the column vector vec. It takes
+// time proportional to the product of the weights of the first row and vec.
+func (mat *CirculantMatrix) MulVec(vec SparseVector) SparseVector {
+	if vec.Len() != mat.size {
+		panic(fmt.Sprintf("vector length %v does not match matrix columns %v", vec.Len(), mat.size))
+	}
+
+	// entry i sums row[k]*vec[j] over j-k = i mod n
+	result := dokVec(mat.size)
+	for _, j := range vec.NonzeroArray() {
+		for _, k := range mat.row.indices {
+			i := (j - k + mat.size) % mat.size
+			result.set(i, 1-result.at(i))
+		}
+	}
+	return CSRVecCopy(result)
+}
+
+// Inverse returns the inverse of this matrix and true, or false if the matrix is
+// singular. The inverse is found from the inverse of the polynomial of the first
+// row modulo x^n + 1.
+func (mat *CirculantMatrix) Inverse() (*CirculantMatrix, bool) {
+	inv, ok := mat.Poly().InverseMod(NewPoly(0, mat.size))
+	if !ok {
+		return nil, false
+	}
+	return CirculantMat(inv.Vec(mat.size)), true
+}
+
+// Equals return true if the m matrix has the same shape and values as this matrix.
+func (mat *CirculantMatrix) Equals(m SparseMat) bool {
+	if c, ok := m.(*CirculantMatrix); ok {
+		return mat.size == c.size && mat.row.Equals(c.row)
+	}
+	return equalRows(mat, m)
+}
+
+// String returns a string representation of this matrix.
+func (mat *CirculantMatrix) String() string {
+	return tableString(mat.size, mat.size, mat.At)
+}
+
+// Slice creates a new CSR matrix containing the slice of data.
+func (mat *CirculantMatrix) Slice(i, j, rows, cols int) SparseMat {
+	return toCSR(mat).Slice(i, j, rows, cols)
+}
+
+// SelectRows returns a new CSR matrix made of the rows given by idx.
+func (mat *CirculantMatrix) SelectRows(idx []int) SparseMat {
+	return toCSR(mat).SelectRows(idx)
+}
+
+// SelectColumns returns a new CSR matrix made of the columns given by idx.
+func (mat *CirculantMatrix) SelectColumns(idx []int) SparseMat {
+	return toCSR(mat).SelectColumns(idx)
+}
+
+// SelectRowsMask returns a new CSR matrix made of the rows where mask is 1.
+func (mat *CirculantMatrix) SelectRowsMask(mask SparseVector) SparseMat {
+	return toCSR(mat).SelectRowsMask(mask)
+}
+
+// SelectColumnsMask returns a new CSR matrix made of the columns where mask is 1.
+func (mat *CirculantMatrix) SelectColumnsMask(mask SparseVector) SparseMat {
+	return toCSR(mat).SelectColumnsMask(mask)
+}
+
+// setRows replaces this matrix by the one with the given rows, which must be circulant.
+func (mat *CirculantMatrix) setRows(op string, rows [][]int) {
+	for i := 1; i < mat.size; i++ {
+		if !equalIndices(rows[i], rotateIndices(rows[0], i, mat.size)) {
+			panic(fmt.Sprintf("%v result is not circulant", op))
+		}
+	}
+	mat.row = &CSRVector{length: mat.size, indices: rows[0]}
+}
+
+// elementwise stores combine of a and b, directly on the first rows when both are
+// circulant.
+func (mat *CirculantMatrix) elementwise(op string, a, b SparseMat, combine func(x, y []int) []int) SparseMat {
+	checkElementwise(op, mat.size, mat.size, a, b)
+
+	ac, aOk := a.(*CirculantMatrix)
+	bc, bOk := b.(*CirculantMatrix)
+	if aOk && bOk {
+		mat.row = &CSRVector{length: mat.size, indices: combine(ac.row.indices, bc.row.indices)}
+		return mat
+	}
+
+	mat.setRows(op, elementwiseRows(a, b, combine))
+	return mat
+}
+
+// Add stores the addition of a and b in this matrix, the result must be circulant.
+func (mat *CirculantMatrix) Add(a, b SparseMat) SparseMat {
+	return mat.elementwise("addition", a, b, addRows)
+}
+
+// XOr executes a piecewise logical XOR on the two matrices and stores the values in
+// this matrix, the result must be circulant.
+func (mat *CirculantMatrix) XOr(a, b SparseMat) SparseMat {
+	return mat.elementwise("XOR", a, b, addRows)
+}
+
+// And executes a piecewise logical AND on the two matrices and stores the values in
+// this matrix, the result must be circulant.
+func (mat *CirculantMatrix) And(a, b SparseMat) SparseMat {
+	return mat.elementwise("AND", a, b, andIndices)
+}
+
+// Or executes a piecewise logical OR on the two matrices and stores the values in
+// this matrix, the result must be circulant.
+func (mat *CirculantMatrix) Or(a, b SparseMat) SparseMat {
+	return mat.elementwise("OR", a, b, orIndices)
+}
+
+// Mul multiplies two matrices and stores the values in this matrix, the result must
+// be circulant. The product of two circulant matrices is found by multiplying
+// their polynomials.
+func (mat *CirculantMatrix) Mul(a, b SparseMat) SparseMat {
+	checkMul(mat, a, b, mat.size, mat.size)
+
+	ac, aOk := a.(*CirculantMatrix)
+	bc, bOk := b.(*CirculantMatrix)
+	if aOk && bOk {
+		product := ac.Poly().MulMod(bc.Poly(), NewPoly(0, mat.size))
+		mat.row = &CSRVector{length: mat.size, indices: product.Exponents()}
+		return mat
+	}
+
+	mat.setRows("multiply", mulRows(matRows(a), matRows(b), mat.size))
+	return mat
+}
+
+// Negate performs a piecewise logical negation.
+func (mat *CirculantMatrix) Negate() SparseMat {
+	mat.row = &CSRVector{length: mat.size, indices: complementIndices(mat.row.indices, mat.size)}
+	return mat
+}
+
+// Zeroize take the current matrix sets all values to 0.
+func (mat *CirculantMatrix) Zeroize() SparseMat {
+	mat.row = &CSRVector{length: mat.size, indices: make([]int, 0)}
+	return mat
+}
+
+const circulantKind = "circulant"
+
+// update applies op to a CSR copy of this matrix and keeps the result, which must
+// be circulant.
+func (mat *CirculantMatrix) update(op string, apply func(m *CSRMatrix)) SparseMat {
+	m := toCSR(mat)
+	apply(m)
+	mat.setRows(op, m.data)
+	return mat
+}
+
+// AddRows adds rows i1 and i2 into row dest, the result must be circulant.
+func (mat *CirculantMatrix) AddRows(i1, i2, dest int) SparseMat {
+	return mat.update("AddRows", func(m *CSRMatrix) { m.AddRows(i1, i2, dest) })
+}
+
+// AppendRow panics, it would break the circulant structure.
+func (mat *CirculantMatrix) AppendRow(vec SparseVector) SparseMat {
+	structureBroken(circulantKind, "AppendRow")
+	return mat
+}
+
+// DeleteColumns panics, it would break the circulant structure.
+func (mat *CirculantMatrix) DeleteColumns(cols ...int) SparseMat {
+	structureBroken(circulantKind, "DeleteColumns")
+	return mat
+}
+
+// DeleteRows panics, it would break the circulant structure.
+func (mat *CirculantMatrix) DeleteRows(rows ...int) SparseMat {
+	structureBroken(circulantKind, "DeleteRows")
+	return mat
+}
+
+// InsertColumns panics, it would break the circulant structure.
+func (mat *CirculantMatrix) InsertColumns(j, count int) SparseMat {
+	structureBroken(circulantKind, "InsertColumns")
+	return mat
+}
+
+// InsertRows panics, it would break the circulant structure.
+func (mat *CirculantMatrix) InsertRows(i, count int) SparseMat {
+	structureBroken(circulantKind, "InsertRows")
+	return mat
+}
+
+// PermuteColumns moves column j to column p[j], the result must be circulant.
+func (mat *CirculantMatrix) PermuteColumns(p Permutation) SparseMat {
+	return mat.update("PermuteColumns", func(m *CSRMatrix) { m.PermuteColumns(p) })
+}
+
+// PermuteRows moves row i to row p[i], the result must be circulant.
+func (mat *CirculantMatrix) PermuteRows(p Permutation) SparseMat {
+	return mat.update("PermuteRows", func(m *CSRMatrix) { m.PermuteRows(p) })
+}
+
+// Resize panics unless the shape is unchanged, any other shape would break the
+// circulant structure.
+func (mat *CirculantMatrix) Resize(rows, cols int) SparseMat {
+	if r, c := mat.Dims(); r != rows || c != cols {
+		structureBroken(circulantKind, "Resize")
+	}
+	return mat
+}
+
+// Set sets the value at row index i and column index j, the result must be circulant.
+func (mat *CirculantMatrix) Set(i, j, value int) SparseMat {
+	if mat.At(i, j) == value%2 {
+		return mat
+	}
+	return mat.update("Set", func(m *CSRMatrix) { m.Set(i, j, value) })
+}
+
+// SetColumn sets column j to vec, the result must be circulant.
+func (mat *CirculantMatrix) SetColumn(j int, vec SparseVector) SparseMat {
+	return mat.update("SetColumn", func(m *CSRMatrix) { m.SetColumn(j, vec) })
+}
+
+// SetColumns sets column idx[k] to column k of a, for every k, the result must be circulant.
+func (mat *CirculantMatrix) SetColumns(idx []int, a SparseMat) SparseMat {
+	return mat.update("SetColumns", func(m *CSRMatrix) { m.SetColumns(idx, a) })
+}
+
+// SetMatrix replaces the values of this matrix with the values of a, with a's
+// origin at (iOffset,jOffset), the result must be circulant.
+func (mat *CirculantMatrix) SetMatrix(a SparseMat, iOffset, jOffset int) SparseMat {
+	return mat.update("SetMatrix", func(m *CSRMatrix) { m.SetMatrix(a, iOffset, jOffset) })
+}
+
+// SetRow sets row i to vec, the result must be circulant.
+func (mat *CirculantMatrix) SetRow(i int, vec SparseVector) SparseMat {
+	return mat.update("SetRow", func(m *CSRMatrix) { m.SetRow(i, vec) })
+}
+
+// SetRows sets row idx[k] to row k of a, for every k, the result must be circulant.
+func (mat *CirculantMatrix) SetRows(idx []int, a SparseMat) SparseMat {
+	return mat.update("SetRows", func(m *CSRMatrix) { m.SetRows(idx, a) })
+}
+
+// SwapColumns swaps columns j1 and j2, the result must be circulant.
+func (mat *CirculantMatrix) SwapColumns(j1, j2 int) SparseMat {
+	return mat.update("SwapColumns", func(m *CSRMatrix) { m.SwapColumns(j1, j2) })
+}
+
+// SwapRows swaps rows i1 and i2, the result must be circulant.
+func (mat *CirculantMatrix) SwapRows(i1, i2 int) SparseMat {
+	return mat.update("SwapRows", func(m *CSRMatrix) { m.SwapRows(i1, i2) })
+}
+
+// ZeroizeRange sets the values of the given range to 0, the result must be circulant.
+// Zeroizing the whole matrix always is.
+func (mat *CirculantMatrix) ZeroizeRange(i, j, rows, cols int) SparseMat {
+	return mat.update("ZeroizeRange", func(m *CSRMatrix) { m.ZeroizeRange(i, j, rows, cols) })
+}
@@ -0,0 +1,238 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func randomCirculant(n int) *CirculantMatrix {
+	return CirculantMat(randomCSRVector(n))
+}
+
+func TestCirculantMat(t *testing.T) {
+	mat := CirculantMat(CSRVec(4, 1, 1, 0, 1))
+	expected := CSRMat(4, 4,
+		1, 1, 0, 1,
+		1, 1, 1, 0,
+		0, 1, 1, 1,
+		1, 0, 1, 1,
+	)
+	if !mat.Equals(expected) || !expected.Equals(mat) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, mat)
+	}
+	if expected := NewPoly(0, 1, 3); !mat.Poly().Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, mat.Poly())
+	}
+}
+
+func TestCirculantMatrix_access(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			n := 1 + rand.Intn(20)
+			mat := randomCirculant(n)
+			expected := CSRMatCopy(mat)
+
+			for r := 0; r < n; r++ {
+				if !mat.Row(r).Equals(expected.Row(r)) {
+					t.Fatalf("row %v expected %v but found %v", r, expected.Row(r), mat.Row(r))
+				}
+				if !mat.Column(r).Equals(expected.Column(r)) {
+					t.Fatalf("column %v expected %v but found %v", r, expected.Column(r), mat.Column(r))
+				}
+			}
+			if !mat.T().Equals(expected.T()) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected.T(), mat.T())
+			}
+
+			vec := randomCSRVector(n)
+			if actual := mat.MulVec(vec); !actual.Equals(CSRVec(n).MatMul(expected, vec)) {
+				t.Fatalf("expected %v but found %v", CSRVec(n).MatMul(expected, vec), actual)
+			}
+		})
+	}
+}
+
+func TestCirculantMatrix_Mul(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			n := 1 + rand.Intn(20)
+			a, b := randomCirculant(n), randomCirculant(n)
+			expected := CSRMat(n, n).Mul(a, b)
+
+			actual := CirculantMat(CSRVec(n)).Mul(a, b)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			actual = CirculantMat(CSRVec(n)).Mul(CSRMatCopy(a), b)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestCirculantMatrix_elementwise(t *testing.T) {
+	a := CirculantMat(CSRVec(5, 1, 1, 0, 0, 1))
+	b := CirculantMat(CSRVec(5, 0, 1, 1, 0, 1))
+
+	tests := []struct {
+		op       func(mat, a, b SparseMat) SparseMat
+		expected SparseVector
+	}{
+		{func(mat, a, b SparseMat) SparseMat { return mat.Add(a, b) }, CSRVec(5, 1, 0, 1, 0, 0)},
+		{func(mat, a, b SparseMat) SparseMat { return mat.XOr(a, b) }, CSRVec(5, 1, 0, 1, 0, 0)},
+		{func(mat, a, b SparseMat) SparseMat { return mat.And(a, b) }, CSRVec(5, 0, 1, 0, 0, 1)},
+		{func(mat, a, b SparseMat) SparseMat { return mat.Or(a, b) }, CSRVec(5, 1, 1, 1, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			expected := CirculantMat(test.expected)
+			if actual := test.op(CirculantMat(CSRVec(5)), a, b); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			if actual := test.op(CirculantMat(CSRVec(5)), CSRMatCopy(a), DOKMatCopy(b)); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestCirculantMatrix_Inverse(t *testing.T) {
+	tests := []struct {
+		row        SparseVector
+		invertible bool
+	}{
+		{CSRVec(3, 1, 0, 0), true},
+		{CSRVec(3, 1, 1, 0), false},
+		{CSRVec(4, 1, 1, 1, 0), true},
+		{CSRVec(4, 0, 0, 0, 0), false},
+		{CSRVec(7, 1, 1, 1, 0, 0, 0, 0), true},
+		{CSRVec(7, 1, 1, 0, 1, 0, 0, 0), false},
+		{CSRVec(7, 1, 0, 1, 1, 1, 0, 0), false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			mat := CirculantMat(test.row)
+			inv, ok := mat.Inverse()
+			if ok != test.invertible {
+				t.Fatalf("expected %v but found %v", test.invertible, ok)
+			}
+			if !ok {
+				return
+			}
+			n := test.row.Len()
+			if actual := CSRMat(n, n).Mul(mat, inv); !actual.Equals(CSRIdentity(n)) {
+				t.Fatalf("expected identity but found \n%v\n", actual)
+			}
+		})
+	}
+}
+
+func TestCirculantMatrix_Slice(t *testing.T) {
+	mat := randomCirculant(10)
+	expected := CSRMatCopy(mat)
+	if actual := mat.Slice(2, 3, 4, 5); !actual.Equals(expected.Slice(2, 3, 4, 5)) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected.Slice(2, 3, 4, 5), actual)
+	}
+	if actual := mat.SelectRows([]int{4, 1}); !actual.Equals(expected.SelectRows([]int{4, 1})) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected.SelectRows([]int{4, 1}), actual)
+	}
+}
+
+func TestCirculantMatrix_Negate(t *testing.T) {
+	mat := CirculantMat(CSRVec(3, 1, 0, 0)).Negate()
+	expected := CSRMat(3, 3, 0, 1, 1, 1, 0, 1, 1, 1, 0)
+	if !mat.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, mat)
+	}
+	if !mat.Zeroize().Equals(CSRMat(3, 3)) {
+		t.Fatalf("expected zero matrix but found \n%v\n", mat)
+	}
+}
+
+func TestCirculantMatrix_update(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			n := 1 + rand.Intn(20)
+			mat := randomCirculant(n)
+			expected := CSRMatCopy(mat)
+
+			// writes of the current values keep the structure
+			r, c := rand.Intn(n), rand.Intn(n)
+			mat.Set(r, c, mat.At(r, c))
+			mat.SetRow(r, mat.Row(r))
+			mat.SetColumn(c, mat.Column(c))
+			mat.SetMatrix(mat.Slice(r, c, n-r, n-c), r, c)
+			mat.SwapRows(r, r)
+			mat.Resize(n, n)
+			if !mat.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, mat)
+			}
+
+			// so does rotating the rows or the columns
+			shift := make(Permutation, n)
+			for k := range shift {
+				shift[k] = (k + r) % n
+			}
+			if actual, expected := mat.PermuteRows(shift), expected.PermuteRows(shift); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			if actual, expected := mat.PermuteColumns(shift), expected.PermuteColumns(shift); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+
+			if !mat.ZeroizeRange(0, 0, n, n).Equals(CSRMat(n, n)) {
+				t.Fatalf("expected zero matrix but found \n%v\n", mat)
+			}
+		})
+	}
+}
+
+func TestCirculantMatrix_panics(t *testing.T) {
+	tests := []func(){
+		func() { CirculantMat(CSRVec(0)) },
+		func() { CirculantMat(CSRVec(3)).Set(0, 0, 1) },
+		func() { CirculantMat(CSRVec(3, 1, 0, 0)).SwapRows(0, 1) },
+		func() { CirculantMat(CSRVec(3, 1, 0, 0)).SetRow(1, CSRVec(3, 1, 0, 0)) },
+		func() { CirculantMat(CSRVec(3, 1, 0, 0)).PermuteRows(Permutation{1, 0, 2}) },
+		func() { CirculantMat(CSRVec(3, 1, 0, 0)).ZeroizeRange(0, 0, 1, 1) },
+		func() { CirculantMat(CSRVec(3)).AppendRow(CSRVec(3)) },
+		func() { CirculantMat(CSRVec(3)).Resize(3, 4) },
+		func() { CirculantMat(CSRVec(3)).Add(CSRIdentity(3), CSRMat(3, 3, 0, 1, 0, 0, 0, 0, 0, 0, 0)) },
+		func() { CirculantMat(CSRVec(3)).Mul(CSRMat(3, 3, 1, 0, 0, 0, 0, 0, 0, 0, 0), CSRIdentity(3)) },
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			test()
+		})
+	}
+}
+
+func TestCirculantMatrix_JSON(t *testing.T) {
+	mat := randomCirculant(20)
+	bs, err := json.Marshal(mat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual CirculantMatrix
+	if err = json.Unmarshal(bs, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !actual.Equals(mat) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", mat, &actual)
+	}
+
+	for _, bad := range []string{`{"Size":0,"Row":[]}`, `{"Size":3,"Row":[3]}`, `{"Size":3,"Row":[1,0]}`} {
+		if err = json.Unmarshal([]byte(bad), &actual); err == nil {
+			t.Fatalf("expected an error for %v", bad)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package sparsemat
+
+import (
+	"fmt"
+)
+
+// DualCode returns a generator matrix of the dual of the code spanned by the rows
+// of g, which is also a parity-check matrix of the code itself.
+func DualCode(g SparseMat) SparseMat {
+	return NullSpace(g)
+}
+
+// Puncture returns the generator matrix of the code spanned by g with the given
+// coordinate positions removed. If a codeword is only supported on the removed
+// positions the rows of the result will be linearly dependent.
+// Puncturing a code is the same as shortening its dual, so for a parity-check
+// matrix h the punctured code has parity-check matrix Shorten(h, positions...).
+func Puncture(g SparseMat, positions ...int) SparseMat {
+	return CSRMatCopy(g).DeleteColumns(positions...)
+}
+
+// Shorten returns the generator matrix of the code made of the codewords of the
+// code spanned by g that are zero on the given positions, with those positions
+// removed. Shortening a code is the same as puncturing its dual, so for a
+// parity-check matrix h the shortened code has parity-check matrix Puncture(h, positions...).
+func Shorten(g SparseMat, positions ...int) SparseMat {
+	_, cols := g.Dims()
+	removed := positionSet(cols, positions)
+
+	// pivoting on the removed positions first leaves the remaining
+	// rows zero on all of them
+	order := make([]int, 0, cols)
+	order = append(order, positions...)
+	for j := 0; j < cols; j++ {
+		if !removed[j] {
+			order = append(order, j)
+		}
+	}
+	reduced, pivots := rowReduce(g, order)
+
+	keep := make([][]int, 0, len(pivots))
+	for i, p := range pivots {
+		if !removed[p] {
+			keep = append(keep, reduced.data[i])
+		}
+	}
+
+	return csrMatFromIndices(len(keep), cols, keep).DeleteColumns(positions...)
+}
+
+// Extend returns the generator matrix of the code spanned by g with an overall
+// parity bit appended to every codeword.
+func Extend(g SparseMat) SparseMat {
+	rows, cols := g.Dims()
+
+	m := csrMat(rows, cols+1)
+	for i := 0; i < rows; i++ {
+		row := g.Row(i).NonzeroArray()
+		if len(row)%2 == 1 {
+			row = append(row, cols)
+		}
+		m.data[i] = row
+	}
+	return m
+}
+
+// DirectSum returns the generator matrix of the direct sum of the codes spanned
+// by a and b, the block diagonal matrix with a on top and b on the bottom.
+func DirectSum(a, b SparseMat) SparseMat {
+	aRows, aCols := a.Dims()
+	bRows, bCols := b.Dims()
+
+	m := csrMat(aRows+bRows, aCols+bCols)
+	m.setMatrix(a, 0, 0)
+	m.setMatrix(b, aRows, aCols)
+	return m
+}
+
+// UUPlusV returns the generator matrix of the (u|u+v) construction from the codes
+// spanned by u and v, which must have the same length:
+//
+//	[ u u ]
+//	[ 0 v ]
+func UUPlusV(u, v SparseMat) SparseMat {
+	uRows, uCols := u.Dims()
+	vRows, vCols := v.Dims()
+	if uCols != vCols {
+		panic(fmt.Sprintf("(u|u+v) requires codes of equal length found %v and %v", uCols, vCols))
+	}
+
+	m := csrMat(uRows+vRows, 2*uCols)
+	m.setMatrix(u, 0, 0)
+	m.setMatrix(u, 0, uCols)
+	m.setMatrix(v, uRows, uCols)
+	return m
+}
@@ -0,0 +1,129 @@
+package sparsemat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestDualCode(t *testing.T) {
+	hg, hh := HammingCode(3)
+	sg, _ := SimplexCode(3)
+	tests := []struct {
+		g        SparseMat
+		expected []int
+	}{
+		{hg, WeightDistribution(sg)},
+		{sg, WeightDistribution(hg)},
+		{hh, WeightDistribution(hg)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := WeightDistribution(DualCode(test.g))
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPuncture(t *testing.T) {
+	g, _ := GolayCode()
+	tests := []struct {
+		g         SparseMat
+		positions []int
+		expected  SparseMat
+		k, d      int
+	}{
+		{CSRMat(2, 4, 1, 1, 0, 1, 0, 1, 1, 0), []int{1, 3}, CSRMat(2, 2, 1, 0, 0, 1), 2, 1},
+		{DOKMat(1, 3, 1, 0, 1), []int{0}, CSRMat(1, 2, 0, 1), 1, 1},
+		{g, []int{23}, nil, 12, 7},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Puncture(test.g, test.positions...)
+			if test.expected != nil && !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+			k, _ := actual.Dims()
+			d, _ := MinDistance(actual)
+			if k != test.k || d != test.d {
+				t.Fatalf("expected dimension %v and distance %v but found %v and %v", test.k, test.d, k, d)
+			}
+		})
+	}
+}
+
+func TestShorten(t *testing.T) {
+	g, _ := GolayCode()
+	hg, _ := HammingCode(3)
+	tests := []struct {
+		g         SparseMat
+		positions []int
+		n, k, d   int
+	}{
+		{g, []int{0}, 23, 11, 8},
+		{g, []int{0, 5, 9}, 21, 9, 8},
+		{hg, []int{6}, 6, 3, 3},
+		{CSRMat(2, 3, 1, 1, 0, 0, 1, 1), []int{0, 2}, 1, 0, 0},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Shorten(test.g, test.positions...)
+			k, n := actual.Dims()
+			d, _ := MinDistance(actual)
+			if n != test.n || k != test.k || d != test.d {
+				t.Fatalf("expected [%v,%v,%v] but found [%v,%v,%v]", test.n, test.k, test.d, n, k, d)
+			}
+		})
+	}
+}
+
+func TestExtend(t *testing.T) {
+	hg, _ := HammingCode(3)
+	tests := []struct {
+		g        SparseMat
+		expected SparseMat
+		d        int
+	}{
+		{CSRMat(2, 3, 1, 0, 0, 1, 1, 0), CSRMat(2, 4, 1, 0, 0, 1, 1, 1, 0, 0), 2},
+		{hg, nil, 4},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Extend(test.g)
+			if test.expected != nil && !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+			if d, _ := MinDistance(actual); d != test.d {
+				t.Fatalf("expected distance %v but found %v", test.d, d)
+			}
+		})
+	}
+}
+
+func TestDirectSum(t *testing.T) {
+	a := CSRMat(1, 2, 1, 1)
+	b := DOKMat(2, 3, 1, 0, 1, 0, 1, 1)
+	expected := CSRMat(3, 5, 1, 1, 0, 0, 0, 0, 0, 1, 0, 1, 0, 0, 0, 1, 1)
+
+	actual := DirectSum(a, b)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestUUPlusV(t *testing.T) {
+	// RM(1,3) is built from RM(1,2) and RM(0,2)
+	u, _ := ReedMullerCode(1, 2)
+	v, _ := ReedMullerCode(0, 2)
+	rm, _ := ReedMullerCode(1, 3)
+
+	actual := UUPlusV(u, v)
+	if !reflect.DeepEqual(WeightDistribution(actual), WeightDistribution(rm)) {
+		t.Fatalf("expected %v but found %v", WeightDistribution(rm), WeightDistribution(actual))
+	}
+	if d, _ := MinDistance(actual); d != 4 {
+		t.Fatalf("expected distance 4 but found %v", d)
+	}
+}
@@ -0,0 +1,128 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// HammingCode returns the generator and parity-check matrices of the binary
+// Hamming code with r parity bits, a [2^r-1, 2^r-1-r, 3] code. Column j of the
+// parity-check matrix is the binary representation of j+1.
+func HammingCode(r int) (g, h SparseMat) {
+	if r < 2 {
+		panic(fmt.Sprintf("hamming code requires at least 2 parity bits, found %v", r))
+	}
+
+	n := 1<<uint(r) - 1
+	data := make([][]int, r)
+	for i := range data {
+		data[i] = make([]int, 0)
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < r; i++ {
+			if (j+1)&(1<<uint(i)) != 0 {
+				data[i] = append(data[i], j)
+			}
+		}
+	}
+
+	hm := csrMatFromIndices(r, n, data)
+	return nullSpace(hm), hm
+}
+
+// SimplexCode returns the generator and parity-check matrices of the simplex code
+// of dimension r, a [2^r-1, r, 2^(r-1)] code which is the dual of HammingCode(r).
+func SimplexCode(r int) (g, h SparseMat) {
+	hg, hh := HammingCode(r)
+	return hh, hg
+}
+
+// ReedMullerCode returns the generator and parity-check matrices of the Reed-Muller
+// code RM(r,m) of length 2^m. The rows of the generator matrix are the evaluations
+// of the monomials of degree at most r on all points of GF(2)^m, ordered by degree.
+// The parity-check matrix is the generator matrix of the dual code RM(m-r-1,m).
+func ReedMullerCode(r, m int) (g, h SparseMat) {
+	if m < 1 || r < 0 || r > m {
+		panic(fmt.Sprintf("reed muller code requires 0 <= r <= m and m >= 1, found r=%v m=%v", r, m))
+	}
+
+	n := 1 << uint(m)
+	if r == m {
+		return CSRIdentity(n), CSRMat(0, n)
+	}
+
+	return reedMullerGenerator(r, m), reedMullerGenerator(m-r-1, m)
+}
+
+func reedMullerGenerator(r, m int) *CSRMatrix {
+	n := 1 << uint(m)
+	data := make([][]int, 0)
+	for degree := 0; degree <= r; degree++ {
+		for mask := 0; mask < n; mask++ {
+			if bits.OnesCount(uint(mask)) != degree {
+				continue
+			}
+			row := make([]int, 0, n>>uint(degree))
+			for x := 0; x < n; x++ {
+				if x&mask == mask {
+					row = append(row, x)
+				}
+			}
+			data = append(data, row)
+		}
+	}
+	return csrMatFromIndices(len(data), n, data)
+}
+
+// RepetitionCode returns the generator and parity-check matrices of the [n,1,n]
+// repetition code. Row i of the parity-check matrix checks bit 0 against bit i+1.
+func RepetitionCode(n int) (g, h SparseMat) {
+	if n < 1 {
+		panic(fmt.Sprintf("repetition code length must be >= 1, found %v", n))
+	}
+
+	ones := make([]int, n)
+	checks := make([][]int, n-1)
+	for j := 0; j < n; j++ {
+		ones[j] = j
+		if j > 0 {
+			checks[j-1] = []int{0, j}
+		}
+	}
+
+	return csrMatFromIndices(1, n, [][]int{ones}), csrMatFromIndices(n-1, n, checks)
+}
+
+// SingleParityCheckCode returns the generator and parity-check matrices of the
+// [n,n-1,2] single parity-check code, the dual of RepetitionCode(n).
+func SingleParityCheckCode(n int) (g, h SparseMat) {
+	rg, rh := RepetitionCode(n)
+	return rh, rg
+}
+
+// golayGenerator is the generator polynomial x^11+x^10+x^6+x^5+x^4+x^2+1 of the
+// cyclic [23,12,7] binary Golay code given by its nonzero exponents.
+var golayGenerator = []int{0, 2, 4, 5, 6, 10, 11}
+
+// GolayCode returns the generator and parity-check matrices of the extended binary
+// Golay code, the self-dual [24,12,8] code. The generator rows are the cyclic shifts
+// of the Golay generator polynomial extended with an overall parity bit, since the
+// code is self-dual the parity-check matrix is the same as the generator matrix.
+func GolayCode() (g, h SparseMat) {
+	const n, k = 24, 12
+
+	data := make([][]int, k)
+	for i := range data {
+		row := make([]int, 0, len(golayGenerator)+1)
+		for _, e := range golayGenerator {
+			row = append(row, e+i)
+		}
+		// every row has odd weight so the parity bit is set
+		data[i] = append(row, n-1)
+	}
+
+	// the extended Golay code is self-dual, its generator matrix is also a
+	// parity-check matrix, h is a copy so the two can be modified independently
+	g = csrMatFromIndices(k, n, data)
+	return g, CSRMatCopy(g)
+}
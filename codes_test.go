@@ -0,0 +1,72 @@
+package sparsemat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCodes(t *testing.T) {
+	hamming3G, hamming3H := HammingCode(3)
+	hamming4G, hamming4H := HammingCode(4)
+	simplexG, simplexH := SimplexCode(3)
+	rm13G, rm13H := ReedMullerCode(1, 3)
+	rm14G, rm14H := ReedMullerCode(1, 4)
+	rm24G, rm24H := ReedMullerCode(2, 4)
+	rm33G, rm33H := ReedMullerCode(3, 3)
+	repG, repH := RepetitionCode(5)
+	spcG, spcH := SingleParityCheckCode(6)
+	golayG, golayH := GolayCode()
+
+	tests := []struct {
+		g, h    SparseMat
+		n, k, d int
+	}{
+		{hamming3G, hamming3H, 7, 4, 3},
+		{hamming4G, hamming4H, 15, 11, 3},
+		{simplexG, simplexH, 7, 3, 4},
+		{rm13G, rm13H, 8, 4, 4},
+		{rm14G, rm14H, 16, 5, 8},
+		{rm24G, rm24H, 16, 11, 4},
+		{rm33G, rm33H, 8, 8, 1},
+		{repG, repH, 5, 1, 5},
+		{spcG, spcH, 6, 5, 2},
+		{golayG, golayH, 24, 12, 8},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			k, n := test.g.Dims()
+			hRows, hCols := test.h.Dims()
+			if n != test.n || hCols != test.n {
+				t.Fatalf("expected length %v but found %v and %v", test.n, n, hCols)
+			}
+			if k != test.k || hRows != test.n-test.k {
+				t.Fatalf("expected dimension %v but found %v with %v checks", test.k, k, hRows)
+			}
+
+			if hRows > 0 {
+				product := CSRMat(k, hRows)
+				product.Mul(test.g, test.h.T())
+				if !product.Equals(CSRMat(k, hRows)) {
+					t.Fatalf("expected G*H^T to be zero but found \n%v", product)
+				}
+			}
+
+			d, _ := MinDistance(test.g)
+			if d != test.d {
+				t.Fatalf("expected minimum distance %v but found %v", test.d, d)
+			}
+		})
+	}
+}
+
+func TestGolayCode_WeightDistribution(t *testing.T) {
+	g, _ := GolayCode()
+	expected := make([]int, 25)
+	expected[0], expected[8], expected[12], expected[16], expected[24] = 1, 759, 2576, 759, 1
+
+	actual := WeightDistribution(g)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
@@ -0,0 +1,87 @@
+package sparsemat
+
+import (
+	"fmt"
+)
+
+// vecLike returns a vector with the given sorted indices using the same backend as v.
+func vecLike(v SparseVector, length int, indices []int) SparseVector {
+	vec := &CSRVector{length: length, indices: indices}
+	if _, ok := v.(*DOKVector); ok {
+		return DOKVecCopy(vec)
+	}
+	return vec
+}
+
+// Concat returns a new vector made of the given vectors one after the other. The
+// result is a DOK vector when the first vector is one and a CSR vector otherwise.
+func Concat(vecs ...SparseVector) SparseVector {
+	if len(vecs) == 0 {
+		panic("concat requires at least one vector")
+	}
+
+	length := 0
+	indices := make([]int, 0)
+	for _, v := range vecs {
+		for _, i := range v.NonzeroArray() {
+			indices = append(indices, length+i)
+		}
+		length += v.Len()
+	}
+	return vecLike(vecs[0], length, indices)
+}
+
+// Split cuts v into consecutive new vectors of the given lengths, which must add up
+// to the length of v. The parts use the same backend as v.
+func Split(v SparseVector, lengths ...int) []SparseVector {
+	total := 0
+	for _, length := range lengths {
+		if length < 0 {
+			panic(fmt.Sprintf("split lengths must be >= 0 found %v", length))
+		}
+		total += length
+	}
+	if total != v.Len() {
+		panic(fmt.Sprintf("split lengths add up to %v but vector length is %v", total, v.Len()))
+	}
+
+	indices := v.NonzeroArray()
+	parts := make([]SparseVector, len(lengths))
+	start := 0
+	for k, length := range lengths {
+		end := findIndex(indices, start+length)
+		part := make([]int, end)
+		for n, i := range indices[:end] {
+			part[n] = i - start
+		}
+		parts[k] = vecLike(v, length, part)
+
+		indices = indices[end:]
+		start += length
+	}
+	return parts
+}
+
+// Interleave returns a new vector where the value at index i of v is moved to
+// index p[i]. The result uses the same backend as v.
+func Interleave(v SparseVector, p Permutation) SparseVector {
+	indices := CSRVecCopy(v).Permute(p).NonzeroArray()
+	return vecLike(v, v.Len(), indices)
+}
+
+// Deinterleave undoes Interleave, returning a new vector where the value at index
+// p[i] of v is moved back to index i.
+func Deinterleave(v SparseVector, p Permutation) SparseVector {
+	return Interleave(v, p.Inverse())
+}
+
+// BlockInterleave returns v written row by row into a block of the given number of
+// rows and read back column by column. The length of v must be a multiple of rows.
+func BlockInterleave(v SparseVector, rows int) SparseVector {
+	return Interleave(v, BlockPermutation(v.Len(), rows))
+}
+
+// BlockDeinterleave undoes BlockInterleave with the same number of rows.
+func BlockDeinterleave(v SparseVector, rows int) SparseVector {
+	return Deinterleave(v, BlockPermutation(v.Len(), rows))
+}
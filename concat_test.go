@@ -0,0 +1,128 @@
+package sparsemat
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		vecs     []SparseVector
+		expected SparseVector
+	}{
+		{[]SparseVector{CSRVec(2, 1, 0), CSRVec(3, 0, 1, 1)}, CSRVec(5, 1, 0, 0, 1, 1)},
+		{[]SparseVector{DOKVec(2, 1, 1), CSRVec(0), CSRVec(1, 1)}, DOKVec(3, 1, 1, 1)},
+		{[]SparseVector{CSRVec(3)}, CSRVec(3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Concat(test.vecs...)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if _, ok := actual.(*DOKVector); ok != isDOKVector(test.expected) {
+				t.Fatalf("expected a %T but found %T", test.expected, actual)
+			}
+		})
+	}
+}
+
+func isDOKVector(v SparseVector) bool {
+	_, ok := v.(*DOKVector)
+	return ok
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		v        SparseVector
+		lengths  []int
+		expected []SparseVector
+	}{
+		{CSRVec(5, 1, 0, 0, 1, 1), []int{2, 3}, []SparseVector{CSRVec(2, 1, 0), CSRVec(3, 0, 1, 1)}},
+		{DOKVec(4, 0, 1, 1, 0), []int{1, 0, 3}, []SparseVector{DOKVec(1), DOKVec(0), DOKVec(3, 1, 1, 0)}},
+		{CSRVec(3, 1, 0, 1), []int{3}, []SparseVector{CSRVec(3, 1, 0, 1)}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Split(test.v, test.lengths...)
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected %v parts but found %v", len(test.expected), len(actual))
+			}
+			for k := range actual {
+				if !actual[k].Equals(test.expected[k]) {
+					t.Fatalf("part %v expected %v but found %v", k, test.expected[k], actual[k])
+				}
+				if isDOKVector(actual[k]) != isDOKVector(test.v) {
+					t.Fatalf("expected a %T but found %T", test.v, actual[k])
+				}
+			}
+			if joined := Concat(actual...); !joined.Equals(test.v) {
+				t.Fatalf("expected %v but found %v", test.v, joined)
+			}
+		})
+	}
+}
+
+func TestSplit_panics(t *testing.T) {
+	tests := []func(){
+		func() { Split(CSRVec(3), 1, 1) },
+		func() { Split(CSRVec(3), 4, -1) },
+		func() { Concat() },
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			test()
+		})
+	}
+}
+
+func TestBlockInterleave(t *testing.T) {
+	tests := []struct {
+		v        SparseVector
+		rows     int
+		expected SparseVector
+	}{
+		{CSRVec(6, 1, 1, 0, 0, 0, 0), 2, CSRVec(6, 1, 0, 1, 0, 0, 0)},
+		{DOKVec(6, 1, 1, 0, 0, 0, 1), 3, DOKVec(6, 1, 0, 0, 1, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := BlockInterleave(test.v, test.rows)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if isDOKVector(actual) != isDOKVector(test.v) {
+				t.Fatalf("expected a %T but found %T", test.v, actual)
+			}
+			if back := BlockDeinterleave(actual, test.rows); !back.Equals(test.v) {
+				t.Fatalf("expected %v but found %v", test.v, back)
+			}
+		})
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			v := randomCSRVector(1 + r.Intn(50))
+			p := RandomPermutation(v.Len(), r)
+
+			actual := Interleave(v, p)
+			for j := 0; j < v.Len(); j++ {
+				if actual.At(p[j]) != v.At(j) {
+					t.Fatalf("index %v was not moved to %v", j, p[j])
+				}
+			}
+			if back := Deinterleave(actual, p); !back.Equals(v) {
+				t.Fatalf("expected %v but found %v", v, back)
+			}
+		})
+	}
+}
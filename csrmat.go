@@ -36,12 +36,48 @@ func (mat *CSRMatrix) UnmarshalJSON(bytes []byte) error {
 		return err
 	}
 
+	if m.Rows < 0 || m.Cols < 0 {
+		return fmt.Errorf("invalid matrix shape (%v,%v)", m.Rows, m.Cols)
+	}
+	if len(m.Data) != m.Rows {
+		return fmt.Errorf("matrix has %v rows but data for %v rows was found", m.Rows, len(m.Data))
+	}
+	if m.Data == nil {
+		m.Data = make([][]int, 0)
+	}
+	for i, row := range m.Data {
+		if row == nil {
+			m.Data[i] = make([]int, 0)
+		}
+		if err = checkIndices(row, m.Cols); err != nil {
+			return fmt.Errorf("row %v %v", i, err)
+		}
+	}
+
 	mat.rows = m.Rows
 	mat.cols = m.Cols
 	mat.data = m.Data
 	return nil
 }
 
+// MarshalBinary encodes the matrix in the compact binary layout also used by gob.
+func (mat *CSRMatrix) MarshalBinary() ([]byte, error) {
+	return marshalMatrix(binaryCSRMatrix, mat), nil
+}
+
+// UnmarshalBinary decodes a matrix written by MarshalBinary of any matrix type.
+func (mat *CSRMatrix) UnmarshalBinary(data []byte) error {
+	rows, cols, indices, err := unmarshalMatrix(data)
+	if err != nil {
+		return err
+	}
+
+	mat.rows = rows
+	mat.cols = cols
+	mat.data = indices
+	return nil
+}
+
 // CSRMat creates a new matrix with the specified number of rows and cols.
 // If values is empty, the matrix will be zeroized.
 // If values are not empty it must have rows*cols items.  The values are expected to
@@ -77,6 +113,16 @@ func csrMat(rows, cols int, values ...int) *CSRMatrix {
 	return &mat
 }
 
+// csrMatFromIndices creates a CSR matrix whose row i has ones at data[i], the
+// indices of each row are expected to be sorted and unique.
+func csrMatFromIndices(rows, cols int, data [][]int) *CSRMatrix {
+	m := csrMat(rows, cols)
+	for i, row := range data {
+		m.data[i] = row
+	}
+	return m
+}
+
 func CSRMatFromVec(vec SparseVector) SparseMat {
 	m := CSRMat(1, vec.Len())
 	m.SetRow(0, vec)
@@ -254,6 +300,38 @@ func cutRange(a []int, start int, end int) []int {
 	return a
 }
 
+// positionSet checks the positions are in [0,length) and returns them as a set.
+func positionSet(length int, positions []int) map[int]bool {
+	set := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		if p < 0 || p >= length {
+			panic(fmt.Sprintf("%v out of range: [0-%v]", p, length-1))
+		}
+		if set[p] {
+			panic(fmt.Sprintf("position %v found more than once", p))
+		}
+		set[p] = true
+	}
+	return set
+}
+
+// deletionMap returns for every index in [0,length) its index once the given
+// indices are removed, or -1 for the removed ones, along with the new length.
+func deletionMap(length int, removed []int) (newIndex []int, newLength int) {
+	set := positionSet(length, removed)
+
+	newIndex = make([]int, length)
+	for k := 0; k < length; k++ {
+		if set[k] {
+			newIndex[k] = -1
+			continue
+		}
+		newIndex[k] = newLength
+		newLength++
+	}
+	return
+}
+
 func (mat *CSRMatrix) at(r, c int) int {
 	cols := mat.data[r]
 	j := findIndex(cols, c)
@@ -669,3 +747,240 @@ func (mat *CSRMatrix) xor(a, b SparseMat) {
 		}
 	}
 }
+
+// remap replaces the matrix by a rows x cols matrix where the one at (i,j) is
+// moved to (rowIndex(i),colIndex(j)) in a single pass, ones mapped to a negative
+// index are dropped. colIndex must be increasing to keep the rows sorted.
+func (mat *CSRMatrix) remap(rows, cols int, rowIndex, colIndex func(int) int) {
+	data := make([][]int, rows)
+	for i := range data {
+		data[i] = make([]int, 0)
+	}
+
+	for i, row := range mat.data {
+		r := rowIndex(i)
+		if r < 0 {
+			continue
+		}
+		kept := make([]int, 0, len(row))
+		for _, j := range row {
+			if c := colIndex(j); c >= 0 {
+				kept = append(kept, c)
+			}
+		}
+		data[r] = kept
+	}
+
+	mat.rows = rows
+	mat.cols = cols
+	mat.data = data
+}
+
+func identityIndex(k int) int {
+	return k
+}
+
+// insertionIndex returns a remapping for inserting count indices before index at.
+func insertionIndex(at, count int) func(int) int {
+	if count < 0 {
+		panic(fmt.Sprintf("count must be >= 0 found %v", count))
+	}
+	return func(k int) int {
+		if k < at {
+			return k
+		}
+		return k + count
+	}
+}
+
+// truncationIndex returns a remapping dropping every index from length on.
+func truncationIndex(length int) func(int) int {
+	return func(k int) int {
+		if k < length {
+			return k
+		}
+		return -1
+	}
+}
+
+// AppendRow adds a new last row to the matrix holding the values of vec.
+func (mat *CSRMatrix) AppendRow(vec SparseVector) SparseMat {
+	if mat.cols != vec.Len() {
+		panic("matrix number of columns must equal length of vector")
+	}
+
+	mat.data = append(mat.data, vec.NonzeroArray())
+	mat.rows++
+	return mat
+}
+
+// InsertRows inserts count zero rows before row i, for i equal to the number
+// of rows they are appended.
+func (mat *CSRMatrix) InsertRows(i, count int) SparseMat {
+	if i < 0 || i > mat.rows {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.rows))
+	}
+
+	mat.remap(mat.rows+count, mat.cols, insertionIndex(i, count), identityIndex)
+	return mat
+}
+
+// DeleteRows removes the given rows from the matrix.
+func (mat *CSRMatrix) DeleteRows(rows ...int) SparseMat {
+	newIndex, newRows := deletionMap(mat.rows, rows)
+
+	mat.remap(newRows, mat.cols, func(i int) int { return newIndex[i] }, identityIndex)
+	return mat
+}
+
+// InsertColumns inserts count zero columns before column j, for j equal to the
+// number of columns they are appended.
+func (mat *CSRMatrix) InsertColumns(j, count int) SparseMat {
+	if j < 0 || j > mat.cols {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", j, mat.cols))
+	}
+
+	mat.remap(mat.rows, mat.cols+count, identityIndex, insertionIndex(j, count))
+	return mat
+}
+
+// DeleteColumns removes the given columns from the matrix.
+func (mat *CSRMatrix) DeleteColumns(cols ...int) SparseMat {
+	newIndex, newCols := deletionMap(mat.cols, cols)
+
+	mat.remap(mat.rows, newCols, identityIndex, func(j int) int { return newIndex[j] })
+	return mat
+}
+
+// Resize changes the shape of the matrix to rows x cols, new rows and columns
+// are zero and the values outside of the new shape are dropped.
+func (mat *CSRMatrix) Resize(rows, cols int) SparseMat {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("resize rows and cols must be >= 0 found (%v,%v)", rows, cols))
+	}
+
+	mat.remap(rows, cols, truncationIndex(rows), truncationIndex(cols))
+	return mat
+}
+
+// SelectRows returns a new matrix made of the rows of this matrix given by idx, in
+// that order. Rows may be selected more than once.
+func (mat *CSRMatrix) SelectRows(idx []int) SparseMat {
+	m := csrMat(len(idx), mat.cols)
+	for k, i := range idx {
+		mat.checkRowBounds(i)
+		m.data[k] = append(m.data[k], mat.data[i]...)
+	}
+	return m
+}
+
+// SelectColumns returns a new matrix made of the columns of this matrix given by
+// idx, in that order. Columns may be selected more than once.
+func (mat *CSRMatrix) SelectColumns(idx []int) SparseMat {
+	// every column is mapped to all the positions it is selected at
+	positions := make(map[int][]int, len(idx))
+	for k, j := range idx {
+		mat.checkColBounds(j)
+		positions[j] = append(positions[j], k)
+	}
+
+	m := csrMat(mat.rows, len(idx))
+	for i, row := range mat.data {
+		for _, j := range row {
+			m.data[i] = append(m.data[i], positions[j]...)
+		}
+		sort.Ints(m.data[i])
+	}
+	return m
+}
+
+// SelectRowsMask returns a new matrix made of the rows of this matrix where mask,
+// whose length must be the number of rows, is 1.
+func (mat *CSRMatrix) SelectRowsMask(mask SparseVector) SparseMat {
+	if mat.rows != mask.Len() {
+		panic("matrix number of rows must equal length of mask")
+	}
+	return mat.SelectRows(mask.NonzeroArray())
+}
+
+// SelectColumnsMask returns a new matrix made of the columns of this matrix where
+// mask, whose length must be the number of columns, is 1.
+func (mat *CSRMatrix) SelectColumnsMask(mask SparseVector) SparseMat {
+	if mat.cols != mask.Len() {
+		panic("matrix number of columns must equal length of mask")
+	}
+	return mat.SelectColumns(mask.NonzeroArray())
+}
+
+// SetRows sets row idx[k] of this matrix to row k of a, for every k. The indices
+// must be unique and a must have len(idx) rows and as many columns as this matrix.
+func (mat *CSRMatrix) SetRows(idx []int, a SparseMat) SparseMat {
+	checkScatter(idx, mat.rows, a, len(idx), mat.cols)
+
+	for k, i := range idx {
+		mat.data[i] = a.Row(k).NonzeroArray()
+	}
+	return mat
+}
+
+// SetColumns sets column idx[k] of this matrix to column k of a, for every k. The
+// indices must be unique and a must have len(idx) columns and as many rows as this matrix.
+func (mat *CSRMatrix) SetColumns(idx []int, a SparseMat) SparseMat {
+	replaced := checkScatter(idx, mat.cols, a, mat.rows, len(idx))
+
+	for i, row := range mat.data {
+		kept := make([]int, 0, len(row))
+		for _, j := range row {
+			if !replaced[j] {
+				kept = append(kept, j)
+			}
+		}
+		for _, k := range a.Row(i).NonzeroArray() {
+			kept = append(kept, idx[k])
+		}
+		sort.Ints(kept)
+		mat.data[i] = kept
+	}
+	return mat
+}
+
+// checkScatter checks the unique indices idx are in [0,length) and that a has
+// the shape (rows,cols), it returns the indices as a set.
+func checkScatter(idx []int, length int, a SparseMat, rows, cols int) map[int]bool {
+	set := positionSet(length, idx)
+
+	aRows, aCols := a.Dims()
+	if aRows != rows || aCols != cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", aRows, aCols, rows, cols))
+	}
+	return set
+}
+
+// PermuteRows moves row i of this matrix to row p[i], which is the product P*M
+// with P the matrix of p.
+func (mat *CSRMatrix) PermuteRows(p Permutation) SparseMat {
+	p.check(mat.rows)
+
+	data := make([][]int, mat.rows)
+	for i, row := range mat.data {
+		data[p[i]] = row
+	}
+	mat.data = data
+	return mat
+}
+
+// PermuteColumns moves column j of this matrix to column p[j], which is the
+// product M*P^T with P the matrix of p.
+func (mat *CSRMatrix) PermuteColumns(p Permutation) SparseMat {
+	p.check(mat.cols)
+
+	for i, row := range mat.data {
+		permuted := make([]int, len(row))
+		for k, j := range row {
+			permuted[k] = p[j]
+		}
+		sort.Ints(permuted)
+		mat.data[i] = permuted
+	}
+	return mat
+}
@@ -823,3 +823,243 @@ func BenchmarkCSRMatrix_T(b *testing.B) {
 		m.T()
 	}
 }
+
+func TestCSRMatrix_AppendRow(t *testing.T) {
+	m := CSRMat(0, 3)
+	m.AppendRow(CSRVec(3, 1, 0, 1))
+	m.AppendRow(DOKVec(3, 0, 1, 0))
+
+	expected := CSRMat(2, 3, 1, 0, 1, 0, 1, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestCSRMatrix_InsertRows(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		i, count int
+		expected SparseMat
+	}{
+		{CSRMat(2, 2, 1, 0, 0, 1), 0, 1, CSRMat(3, 2, 0, 0, 1, 0, 0, 1)},
+		{CSRMat(2, 2, 1, 0, 0, 1), 1, 2, CSRMat(4, 2, 1, 0, 0, 0, 0, 0, 0, 1)},
+		{CSRMat(2, 2, 1, 0, 0, 1), 2, 1, CSRMat(3, 2, 1, 0, 0, 1, 0, 0)},
+		{CSRMat(2, 2, 1, 0, 0, 1), 1, 0, CSRMat(2, 2, 1, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.InsertRows(test.i, test.count)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_DeleteRows(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		rows     []int
+		expected SparseMat
+	}{
+		{CSRMat(3, 2, 1, 0, 0, 1, 1, 1), []int{1}, CSRMat(2, 2, 1, 0, 1, 1)},
+		{CSRMat(3, 2, 1, 0, 0, 1, 1, 1), []int{2, 0}, CSRMat(1, 2, 0, 1)},
+		{CSRMat(3, 2, 1, 0, 0, 1, 1, 1), []int{}, CSRMat(3, 2, 1, 0, 0, 1, 1, 1)},
+		{CSRMat(3, 2, 1, 0, 0, 1, 1, 1), []int{0, 1, 2}, CSRMat(0, 2)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.DeleteRows(test.rows...)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_InsertColumns(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		j, count int
+		expected SparseMat
+	}{
+		{CSRMat(2, 2, 1, 0, 1, 1), 0, 1, CSRMat(2, 3, 0, 1, 0, 0, 1, 1)},
+		{CSRMat(2, 2, 1, 0, 1, 1), 1, 2, CSRMat(2, 4, 1, 0, 0, 0, 1, 0, 0, 1)},
+		{CSRMat(2, 2, 1, 0, 1, 1), 2, 1, CSRMat(2, 3, 1, 0, 0, 1, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.InsertColumns(test.j, test.count)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_DeleteColumns(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		cols     []int
+		expected SparseMat
+	}{
+		{CSRMat(2, 3, 1, 0, 1, 0, 1, 1), []int{1}, CSRMat(2, 2, 1, 1, 0, 1)},
+		{CSRMat(2, 3, 1, 0, 1, 0, 1, 1), []int{2, 0}, CSRMat(2, 1, 0, 1)},
+		{CSRMat(2, 3, 1, 0, 1, 0, 1, 1), []int{}, CSRMat(2, 3, 1, 0, 1, 0, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.DeleteColumns(test.cols...)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_DeleteColumns_duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a duplicate column")
+		}
+	}()
+	CSRIdentity(3).DeleteColumns(1, 1)
+}
+
+func TestCSRMatrix_Resize(t *testing.T) {
+	tests := []struct {
+		input      SparseMat
+		rows, cols int
+		expected   SparseMat
+	}{
+		{CSRIdentity(2), 3, 3, CSRMat(3, 3, 1, 0, 0, 0, 1, 0, 0, 0, 0)},
+		{CSRIdentity(3), 2, 2, CSRIdentity(2)},
+		{CSRIdentity(3), 1, 3, CSRMat(1, 3, 1, 0, 0)},
+		{CSRIdentity(3), 0, 0, CSRMat(0, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Resize(test.rows, test.cols)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_SelectRows(t *testing.T) {
+	m := CSRMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+	tests := []struct {
+		idx      []int
+		expected SparseMat
+	}{
+		{[]int{2, 0}, CSRMat(2, 3, 1, 0, 1, 1, 0, 0)},
+		{[]int{1, 1}, CSRMat(2, 3, 0, 1, 1, 0, 1, 1)},
+		{[]int{}, CSRMat(0, 3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := m.SelectRows(test.idx)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_SelectColumns(t *testing.T) {
+	m := CSRMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+	tests := []struct {
+		idx      []int
+		expected SparseMat
+	}{
+		{[]int{2, 0}, CSRMat(3, 2, 0, 1, 1, 0, 1, 1)},
+		{[]int{0, 0, 1}, CSRMat(3, 3, 1, 1, 0, 0, 0, 1, 1, 1, 0)},
+		{[]int{}, CSRMat(3, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := m.SelectColumns(test.idx)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCSRMatrix_SelectMask(t *testing.T) {
+	m := CSRMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+
+	expected := CSRMat(2, 3, 1, 0, 0, 1, 0, 1)
+	if actual := m.SelectRowsMask(CSRVec(3, 1, 0, 1)); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+
+	expected = CSRMat(3, 2, 0, 0, 1, 1, 0, 1)
+	if actual := m.SelectColumnsMask(DOKVec(3, 0, 1, 1)); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestCSRMatrix_SetRows(t *testing.T) {
+	m := CSRIdentity(3)
+	m.SetRows([]int{2, 0}, CSRMat(2, 3, 1, 1, 0, 0, 0, 0))
+
+	expected := CSRMat(3, 3, 0, 0, 0, 0, 1, 0, 1, 1, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestCSRMatrix_SetColumns(t *testing.T) {
+	m := CSRIdentity(3)
+	m.SetColumns([]int{2, 0}, CSRMat(3, 2, 1, 0, 1, 0, 0, 0))
+
+	expected := CSRMat(3, 3, 0, 0, 1, 0, 1, 1, 0, 0, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestCSRMatrix_SelectSetColumns_random(t *testing.T) {
+	m := randomMatrix(50, 40)
+	idx := rand.Perm(40)[:15]
+
+	sub := m.SelectColumns(idx)
+	for k, j := range idx {
+		if !sub.Column(k).Equals(m.Column(j)) {
+			t.Fatalf("expected column %v to be column %v", k, j)
+		}
+	}
+
+	cp := CSRMatCopy(m)
+	cp.SetColumns(idx, CSRMat(50, 15))
+	cp.SetColumns(idx, sub)
+	if !cp.Equals(m) {
+		t.Fatalf("expected %v but found %v", m, cp)
+	}
+}
+
+func TestCSRMatrix_PermuteRows(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	m := CSRMatCopy(randomMatrix(12, 9))
+	p := RandomPermutation(12, r)
+
+	expected := CSRMat(12, 9)
+	expected.Mul(p.Matrix(), m)
+	if actual := m.PermuteRows(p); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestCSRMatrix_PermuteColumns(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	m := CSRMatCopy(randomMatrix(12, 9))
+	p := RandomPermutation(9, r)
+
+	expected := CSRMat(12, 9)
+	expected.Mul(m, p.Matrix().T())
+	if actual := m.PermuteColumns(p); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
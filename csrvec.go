@@ -3,6 +3,7 @@ package sparsemat
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/olekukonko/tablewriter"
@@ -31,11 +32,38 @@ func (vec *CSRVector) UnmarshalJSON(bytes []byte) error {
 	if err != nil {
 		return err
 	}
+
+	if v.Length < 0 {
+		return fmt.Errorf("invalid vector length %v", v.Length)
+	}
+	if v.Indices == nil {
+		v.Indices = make([]int, 0)
+	}
+	if err = checkIndices(v.Indices, v.Length); err != nil {
+		return err
+	}
 	vec.length = v.Length
 	vec.indices = v.Indices
 	return nil
 }
 
+// MarshalBinary encodes the vector in the compact binary layout also used by gob.
+func (vec *CSRVector) MarshalBinary() ([]byte, error) {
+	return marshalVector(binaryCSRVector, vec), nil
+}
+
+// UnmarshalBinary decodes a vector written by MarshalBinary of any vector type.
+func (vec *CSRVector) UnmarshalBinary(data []byte) error {
+	length, indices, err := unmarshalVector(data)
+	if err != nil {
+		return err
+	}
+
+	vec.length = length
+	vec.indices = indices
+	return nil
+}
+
 func CSRVec(length int, values ...int) SparseVector {
 	if len(values) != 0 {
 		if length != len(values) {
@@ -421,3 +449,157 @@ func (vec *CSRVector) NextSet(startingIndex int) (index int, has bool) {
 
 	return vec.indices[index], true
 }
+
+// Append extends the vector with the values of a.
+func (vec *CSRVector) Append(a SparseVector) SparseVector {
+	for _, i := range a.NonzeroArray() {
+		vec.indices = append(vec.indices, vec.length+i)
+	}
+	vec.length += a.Len()
+	return vec
+}
+
+// Truncate shortens the vector to the given length dropping the values past it.
+func (vec *CSRVector) Truncate(length int) SparseVector {
+	if length < 0 || length > vec.length {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", length, vec.length))
+	}
+
+	vec.indices = vec.indices[:findIndex(vec.indices, length)]
+	vec.length = length
+	return vec
+}
+
+// Permute moves the value at index i of this vector to index p[i].
+func (vec *CSRVector) Permute(p Permutation) SparseVector {
+	p.check(vec.length)
+
+	permuted := make([]int, len(vec.indices))
+	for k, i := range vec.indices {
+		permuted[k] = p[i]
+	}
+	sort.Ints(permuted)
+	vec.indices = permuted
+	return vec
+}
+
+// rotateIndices returns the sorted indices, in [0,n), rotated right by shift.
+func rotateIndices(indices []int, shift, n int) []int {
+	shift = ((shift % n) + n) % n
+	split := findIndex(indices, n-shift)
+
+	rotated := make([]int, 0, len(indices))
+	for _, k := range indices[split:] {
+		rotated = append(rotated, k+shift-n)
+	}
+	for _, k := range indices[:split] {
+		rotated = append(rotated, k+shift)
+	}
+	return rotated
+}
+
+func checkShift(k int) {
+	if k < 0 {
+		panic(fmt.Sprintf("shift must be >= 0 found %v", k))
+	}
+}
+
+// RotateLeft cyclically moves the value at index i to index (i-k) mod Len().
+func (vec *CSRVector) RotateLeft(k int) SparseVector {
+	return vec.RotateRight(-k)
+}
+
+// RotateRight cyclically moves the value at index i to index (i+k) mod Len().
+func (vec *CSRVector) RotateRight(k int) SparseVector {
+	if vec.length > 0 {
+		vec.indices = rotateIndices(vec.indices, k, vec.length)
+	}
+	return vec
+}
+
+// ShiftLeft moves the value at index i to index i-k, values shifted past index 0
+// are dropped and the vacated indices are set to 0.
+func (vec *CSRVector) ShiftLeft(k int) SparseVector {
+	checkShift(k)
+
+	kept := vec.indices[findIndex(vec.indices, k):]
+	shifted := make([]int, len(kept))
+	for n, i := range kept {
+		shifted[n] = i - k
+	}
+	vec.indices = shifted
+	return vec
+}
+
+// ShiftRight moves the value at index i to index i+k, values shifted past the end
+// are dropped and the vacated indices are set to 0.
+func (vec *CSRVector) ShiftRight(k int) SparseVector {
+	checkShift(k)
+
+	kept := vec.indices[:findIndex(vec.indices, vec.length-k)]
+	shifted := make([]int, len(kept))
+	for n, i := range kept {
+		shifted[n] = i + k
+	}
+	vec.indices = shifted
+	return vec
+}
+
+// Reverse reverses the order of the values, moving index i to Len()-1-i.
+func (vec *CSRVector) Reverse() SparseVector {
+	reversed := make([]int, len(vec.indices))
+	for n, i := range vec.indices {
+		reversed[len(reversed)-1-n] = vec.length - 1 - i
+	}
+	vec.indices = reversed
+	return vec
+}
+
+// Rank returns the number of ones at the indices before i, i can be Len() to count
+// all the ones.
+func (vec *CSRVector) Rank(i int) int {
+	if i < 0 || i > vec.length {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, vec.length))
+	}
+	return findIndex(vec.indices, i)
+}
+
+// Select returns the index of the k-th one, counting from 0. If the vector has k
+// or fewer ones has bool will be set to false.
+func (vec *CSRVector) Select(k int) (index int, has bool) {
+	if k < 0 || k >= len(vec.indices) {
+		return -1, false
+	}
+	return vec.indices[k], true
+}
+
+// PrevSet returns the previous bit which is set starting from startingIndex, so if
+// the startingIndex is set it will be returned, if not it will be the previous bit.
+// If no bits are found has bool will be set to false.
+func (vec *CSRVector) PrevSet(startingIndex int) (index int, has bool) {
+	vec.checkBounds(startingIndex)
+
+	index = findIndex(vec.indices, startingIndex+1) - 1
+	if index < 0 {
+		return -1, false
+	}
+	return vec.indices[index], true
+}
+
+// FirstSet returns the lowest index which is set, if none are has bool will be set
+// to false.
+func (vec *CSRVector) FirstSet() (index int, has bool) {
+	if len(vec.indices) == 0 {
+		return -1, false
+	}
+	return vec.indices[0], true
+}
+
+// LastSet returns the highest index which is set, if none are has bool will be set
+// to false.
+func (vec *CSRVector) LastSet() (index int, has bool) {
+	if len(vec.indices) == 0 {
+		return -1, false
+	}
+	return vec.indices[len(vec.indices)-1], true
+}
@@ -418,3 +418,232 @@ func TestCSRVector_NextSet(t *testing.T) {
 		})
 	}
 }
+
+func TestCSRVector_Append(t *testing.T) {
+	tests := []struct {
+		input, a SparseVector
+		expected SparseVector
+	}{
+		{CSRVec(2, 1, 0), CSRVec(3, 0, 1, 1), CSRVec(5, 1, 0, 0, 1, 1)},
+		{CSRVec(0), DOKVec(2, 1, 0), CSRVec(2, 1, 0)},
+		{CSRVec(2, 0, 1), CSRVec(0), CSRVec(2, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Append(test.a)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Truncate(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		length   int
+		expected SparseVector
+	}{
+		{CSRVec(5, 1, 0, 0, 1, 1), 3, CSRVec(3, 1, 0, 0)},
+		{CSRVec(5, 1, 0, 0, 1, 1), 5, CSRVec(5, 1, 0, 0, 1, 1)},
+		{CSRVec(5, 1, 0, 0, 1, 1), 0, CSRVec(0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Truncate(test.length)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Permute(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		p        Permutation
+		expected SparseVector
+	}{
+		{CSRVec(3, 1, 1, 0), Permutation{2, 0, 1}, CSRVec(3, 1, 0, 1)},
+		{CSRVec(4, 1, 0, 0, 1), Permutation{3, 2, 1, 0}, CSRVec(4, 1, 0, 0, 1)},
+		{CSRVec(4, 1, 0, 0, 0), Permutation{3, 2, 1, 0}, CSRVec(4, 0, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Permute(test.p)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Rotate(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		k        int
+		expected SparseVector
+	}{
+		{CSRVec(5, 1, 1, 0, 0, 0), 1, CSRVec(5, 0, 1, 1, 0, 0)},
+		{CSRVec(5, 1, 0, 0, 0, 1), 2, CSRVec(5, 0, 1, 1, 0, 0)},
+		{CSRVec(5, 1, 0, 1, 0, 1), 5, CSRVec(5, 1, 0, 1, 0, 1)},
+		{CSRVec(5, 1, 0, 1, 0, 1), -1, CSRVec(5, 0, 1, 0, 1, 1)},
+		{CSRVec(5, 1, 0, 1, 0, 1), 12, CSRVec(5, 0, 1, 1, 0, 1)},
+		{CSRVec(0), 3, CSRVec(0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRVecCopy(test.input).RotateRight(test.k)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			actual.RotateLeft(test.k)
+			if !actual.Equals(test.input) {
+				t.Fatalf("expected %v but found %v", test.input, actual)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Shift(t *testing.T) {
+	tests := []struct {
+		input       SparseVector
+		k           int
+		left, right SparseVector
+	}{
+		{CSRVec(5, 1, 1, 0, 0, 1), 0, CSRVec(5, 1, 1, 0, 0, 1), CSRVec(5, 1, 1, 0, 0, 1)},
+		{CSRVec(5, 1, 1, 0, 0, 1), 1, CSRVec(5, 1, 0, 0, 1, 0), CSRVec(5, 0, 1, 1, 0, 0)},
+		{CSRVec(5, 1, 1, 0, 0, 1), 4, CSRVec(5, 1, 0, 0, 0, 0), CSRVec(5, 0, 0, 0, 0, 1)},
+		{CSRVec(5, 1, 1, 0, 0, 1), 7, CSRVec(5), CSRVec(5)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := CSRVecCopy(test.input).ShiftLeft(test.k); !actual.Equals(test.left) {
+				t.Fatalf("expected %v but found %v", test.left, actual)
+			}
+			if actual := CSRVecCopy(test.input).ShiftRight(test.k); !actual.Equals(test.right) {
+				t.Fatalf("expected %v but found %v", test.right, actual)
+			}
+		})
+	}
+}
+
+func TestCSRVector_ShiftPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	CSRVec(5).ShiftLeft(-1)
+}
+
+func TestCSRVector_Reverse(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		expected SparseVector
+	}{
+		{CSRVec(5, 1, 1, 0, 0, 0), CSRVec(5, 0, 0, 0, 1, 1)},
+		{CSRVec(4, 1, 0, 1, 1), CSRVec(4, 1, 1, 0, 1)},
+		{CSRVec(3), CSRVec(3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Reverse()
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Rank(t *testing.T) {
+	vec := CSRVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		i, expected int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := vec.Rank(test.i); actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCSRVector_Select(t *testing.T) {
+	vec := CSRVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		k        int
+		index    int
+		expected bool
+	}{
+		{0, 1, true},
+		{1, 2, true},
+		{2, 5, true},
+		{3, -1, false},
+		{-1, -1, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			index, has := vec.Select(test.k)
+			if index != test.index || has != test.expected {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.index, test.expected, index, has)
+			}
+			if has && vec.Rank(index) != test.k {
+				t.Fatalf("expected rank %v but found %v", test.k, vec.Rank(index))
+			}
+		})
+	}
+}
+
+func TestCSRVector_PrevSet(t *testing.T) {
+	vec := CSRVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		start    int
+		index    int
+		expected bool
+	}{
+		{0, -1, false},
+		{1, 1, true},
+		{2, 2, true},
+		{4, 2, true},
+		{5, 5, true},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			index, has := vec.PrevSet(test.start)
+			if index != test.index || has != test.expected {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.index, test.expected, index, has)
+			}
+		})
+	}
+}
+
+func TestCSRVector_FirstLastSet(t *testing.T) {
+	tests := []struct {
+		vec         SparseVector
+		first, last int
+		has         bool
+	}{
+		{CSRVec(6, 0, 1, 1, 0, 0, 1), 1, 5, true},
+		{CSRVec(3, 1, 0, 0), 0, 0, true},
+		{CSRVec(3), -1, -1, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if index, has := test.vec.FirstSet(); index != test.first || has != test.has {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.first, test.has, index, has)
+			}
+			if index, has := test.vec.LastSet(); index != test.last || has != test.has {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.last, test.has, index, has)
+			}
+		})
+	}
+}
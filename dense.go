@@ -0,0 +1,125 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// CSRMatFromInts creates a new matrix from the dense rows of values, every row
+// must have the same length. Values are reduced mod 2 so any even value gives a 0
+// and any odd value (including negative ones) gives a 1.
+func CSRMatFromInts(values [][]int) SparseMat {
+	cols := denseCols(len(values), func(i int) int { return len(values[i]) })
+
+	m := csrMat(len(values), cols)
+	for i, row := range values {
+		for j, v := range row {
+			if v&1 == 1 {
+				m.data[i] = append(m.data[i], j)
+			}
+		}
+	}
+	return m
+}
+
+// CSRMatFromBools creates a new matrix from the dense rows of values where true
+// is a 1, every row must have the same length.
+func CSRMatFromBools(values [][]bool) SparseMat {
+	cols := denseCols(len(values), func(i int) int { return len(values[i]) })
+
+	m := csrMat(len(values), cols)
+	for i, row := range values {
+		for j, v := range row {
+			if v {
+				m.data[i] = append(m.data[i], j)
+			}
+		}
+	}
+	return m
+}
+
+// denseCols returns the common length of the rows of a dense matrix
+// and panics if the rows are ragged.
+func denseCols(rows int, rowLen func(i int) int) int {
+	if rows == 0 {
+		return 0
+	}
+	cols := rowLen(0)
+	for i := 1; i < rows; i++ {
+		if rowLen(i) != cols {
+			panic(fmt.Sprintf("row %v has length %v expected %v", i, rowLen(i), cols))
+		}
+	}
+	return cols
+}
+
+// wordsPerRow is the number of 64 bit words used to pack a row of cols bits.
+func wordsPerRow(cols int) int {
+	return (cols + 63) / 64
+}
+
+// CSRMatFromWords creates a new rows x cols matrix from rows packed into 64 bit
+// words as written by MatToWords. Every row takes (cols+63)/64 consecutive words and
+// column j of a row is bit j%64 of its word j/64, least significant bit first.
+// Bits past the last column must be 0.
+func CSRMatFromWords(rows, cols int, words []uint64) SparseMat {
+	perRow := wordsPerRow(cols)
+	if len(words) != rows*perRow {
+		panic(fmt.Sprintf("matrix words length (%v) to length mismatch expected %v", len(words), rows*perRow))
+	}
+
+	m := csrMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for w, word := range words[i*perRow : (i+1)*perRow] {
+			for word != 0 {
+				j := w*64 + bits.TrailingZeros64(word)
+				if j >= cols {
+					panic(fmt.Sprintf("row %v has bit %v set past the last column %v", i, j, cols-1))
+				}
+				m.data[i] = append(m.data[i], j)
+				word &= word - 1
+			}
+		}
+	}
+	return m
+}
+
+// MatToInts returns the matrix as dense rows of 0's and 1's.
+func MatToInts(m SparseMat) [][]int {
+	rows, cols := m.Dims()
+	values := make([][]int, rows)
+	for i := range values {
+		values[i] = make([]int, cols)
+		for _, j := range m.Row(i).NonzeroArray() {
+			values[i][j] = 1
+		}
+	}
+	return values
+}
+
+// MatToBools returns the matrix as dense rows where true is a 1.
+func MatToBools(m SparseMat) [][]bool {
+	rows, cols := m.Dims()
+	values := make([][]bool, rows)
+	for i := range values {
+		values[i] = make([]bool, cols)
+		for _, j := range m.Row(i).NonzeroArray() {
+			values[i][j] = true
+		}
+	}
+	return values
+}
+
+// MatToWords returns the rows of the matrix packed into 64 bit words in the layout
+// read by CSRMatFromWords.
+func MatToWords(m SparseMat) []uint64 {
+	rows, cols := m.Dims()
+	perRow := wordsPerRow(cols)
+	words := make([]uint64, rows*perRow)
+	for i := 0; i < rows; i++ {
+		for _, j := range m.Row(i).NonzeroArray() {
+			words[i*perRow+j/64] |= 1 << uint(j%64)
+		}
+	}
+	return words
+}
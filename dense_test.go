@@ -0,0 +1,102 @@
+package sparsemat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCSRMatFromInts(t *testing.T) {
+	tests := []struct {
+		values   [][]int
+		expected SparseMat
+	}{
+		{[][]int{}, CSRMat(0, 0)},
+		{[][]int{{1, 0, 1}, {0, 1, 0}}, CSRMat(2, 3, 1, 0, 1, 0, 1, 0)},
+		{[][]int{{2, 3}, {-1, -2}}, CSRMat(2, 2, 0, 1, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRMatFromInts(test.values)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCSRMatFromIntsRagged(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for ragged rows")
+		}
+	}()
+	CSRMatFromInts([][]int{{1, 0}, {1}})
+}
+
+func TestCSRMatFromBools(t *testing.T) {
+	expected := CSRMat(2, 3, 1, 0, 1, 0, 1, 1)
+	actual := CSRMatFromBools([][]bool{{true, false, true}, {false, true, true}})
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\nbut found\n%v", expected, actual)
+	}
+}
+
+func TestCSRMatFromWords(t *testing.T) {
+	tests := []struct {
+		rows, cols int
+		words      []uint64
+		expected   SparseMat
+	}{
+		{2, 3, []uint64{0x5, 0x2}, CSRMat(2, 3, 1, 0, 1, 0, 1, 0)},
+		{1, 0, []uint64{}, CSRMat(1, 0)},
+		{1, 66, []uint64{0x1, 0x2}, csrMatFromIndices(1, 66, [][]int{{0, 65}})},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRMatFromWords(test.rows, test.cols, test.words)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCSRMatFromWordsPadding(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a bit past the last column")
+		}
+	}()
+	CSRMatFromWords(1, 3, []uint64{0x8})
+}
+
+func TestDenseRoundTrip(t *testing.T) {
+	for i, size := range []int{1, 10, 64, 65, 130} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			m := randomMatrix(size/2+1, size)
+
+			if actual := CSRMatFromInts(MatToInts(m)); !actual.Equals(m) {
+				t.Fatalf("ints: expected \n%v\nbut found\n%v", m, actual)
+			}
+			if actual := CSRMatFromBools(MatToBools(m)); !actual.Equals(m) {
+				t.Fatalf("bools: expected \n%v\nbut found\n%v", m, actual)
+			}
+			rows, cols := m.Dims()
+			if actual := CSRMatFromWords(rows, cols, MatToWords(m)); !actual.Equals(m) {
+				t.Fatalf("words: expected \n%v\nbut found\n%v", m, actual)
+			}
+		})
+	}
+}
+
+func TestMatToWords(t *testing.T) {
+	m := DOKMat(2, 65, make([]int, 130)...)
+	m.Set(0, 1, 1)
+	m.Set(1, 64, 1)
+
+	expected := []uint64{0x2, 0x0, 0x0, 0x1}
+	if actual := MatToWords(m); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
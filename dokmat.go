@@ -12,7 +12,7 @@ import (
 
 type DOKMatrix struct {
 	rowValues map[int]map[int]int //hold rowValues for (X,Y)
-	colValues map[int]map[int]int //easy access to (Y,X)
+	colValues map[int]map[int]int //easy access to (Y,X), created on first use
 	rows      int                 // total number rows available to this matrix
 	cols      int                 // total number cols available to this matrix
 }
@@ -38,13 +38,31 @@ func (mat *DOKMatrix) UnmarshalJSON(bytes []byte) error {
 	for i := 0; i < rows; i++ {
 		mat.rowValues[i] = make(map[int]int)
 	}
-	for j := 0; j < cols; j++ {
-		mat.colValues[j] = make(map[int]int)
+
+	for i, row := range csr.data {
+		for _, j := range row {
+			mat.set(i, j, 1)
+		}
 	}
+	return nil
+}
 
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			mat.set(i, j, csr.At(i, j))
+// MarshalBinary encodes the matrix in the compact binary layout also used by gob.
+func (mat *DOKMatrix) MarshalBinary() ([]byte, error) {
+	return marshalMatrix(binaryDOKMatrix, mat), nil
+}
+
+// UnmarshalBinary decodes a matrix written by MarshalBinary of any matrix type.
+func (mat *DOKMatrix) UnmarshalBinary(data []byte) error {
+	rows, cols, indices, err := unmarshalMatrix(data)
+	if err != nil {
+		return err
+	}
+
+	*mat = *dokMat(rows, cols)
+	for i, row := range indices {
+		for _, j := range row {
+			mat.set(i, j, 1)
 		}
 	}
 	return nil
@@ -73,9 +91,6 @@ func dokMat(rows, cols int, values ...int) *DOKMatrix {
 	for i := 0; i < rows; i++ {
 		mat.rowValues[i] = make(map[int]int)
 	}
-	for j := 0; j < cols; j++ {
-		mat.colValues[j] = make(map[int]int)
-	}
 
 	if len(values) > 0 {
 		for i := 0; i < rows; i++ {
@@ -332,6 +347,12 @@ func (mat *DOKMatrix) set(r, c, value int) {
 		return
 	}
 
+	if mat.rowValues[r] == nil {
+		mat.rowValues[r] = make(map[int]int)
+	}
+	if mat.colValues[c] == nil {
+		mat.colValues[c] = make(map[int]int)
+	}
 	mat.rowValues[r][c] = value
 	mat.colValues[c][r] = value
 }
@@ -343,8 +364,7 @@ func (mat *DOKMatrix) T() SparseMat {
 
 	for i, cols := range mat.rowValues {
 		for j := range cols {
-			m.rowValues[j][i] = 1
-			m.colValues[i][j] = 1
+			m.set(j, i, 1)
 		}
 	}
 
@@ -484,7 +504,7 @@ func (mat *DOKMatrix) SetColumn(j int, vec SparseVector) SparseMat {
 	for i := 0; i < mat.rows; i++ {
 		_, has := mat.colValues[j][i]
 		if has {
-			mat.rowValues[i][j] = 1
+			mat.set(i, j, 1)
 		} else {
 			_, has = mat.rowValues[i][j]
 			if has {
@@ -522,7 +542,7 @@ func (mat *DOKMatrix) SetRow(i int, vec SparseVector) SparseMat {
 	for j := 0; j < mat.cols; j++ {
 		_, has := mat.rowValues[i][j]
 		if has {
-			mat.colValues[j][i] = 1
+			mat.set(i, j, 1)
 		} else {
 			_, has = mat.colValues[j][i]
 			if has {
@@ -704,3 +724,181 @@ func (mat *DOKMatrix) xor(a, b SparseMat) {
 		}
 	}
 }
+
+// remap replaces the matrix by a rows x cols matrix where the one at (i,j) is
+// moved to (rowIndex(i),colIndex(j)) in a single pass, ones mapped to a negative
+// index are dropped.
+func (mat *DOKMatrix) remap(rows, cols int, rowIndex, colIndex func(int) int) {
+	m := dokMat(rows, cols)
+	for i, cs := range mat.rowValues {
+		r := rowIndex(i)
+		if r < 0 {
+			continue
+		}
+		for j := range cs {
+			if c := colIndex(j); c >= 0 {
+				m.set(r, c, 1)
+			}
+		}
+	}
+
+	*mat = *m
+}
+
+// AppendRow adds a new last row to the matrix holding the values of vec.
+func (mat *DOKMatrix) AppendRow(vec SparseVector) SparseMat {
+	if mat.cols != vec.Len() {
+		panic("matrix number of columns must equal length of vector")
+	}
+
+	i := mat.rows
+	mat.rows++
+	mat.rowValues[i] = make(map[int]int)
+	for _, j := range vec.NonzeroArray() {
+		mat.set(i, j, 1)
+	}
+	return mat
+}
+
+// InsertRows inserts count zero rows before row i, for i equal to the number
+// of rows they are appended.
+func (mat *DOKMatrix) InsertRows(i, count int) SparseMat {
+	if i < 0 || i > mat.rows {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.rows))
+	}
+
+	mat.remap(mat.rows+count, mat.cols, insertionIndex(i, count), identityIndex)
+	return mat
+}
+
+// DeleteRows removes the given rows from the matrix.
+func (mat *DOKMatrix) DeleteRows(rows ...int) SparseMat {
+	newIndex, newRows := deletionMap(mat.rows, rows)
+
+	mat.remap(newRows, mat.cols, func(i int) int { return newIndex[i] }, identityIndex)
+	return mat
+}
+
+// InsertColumns inserts count zero columns before column j, for j equal to the
+// number of columns they are appended.
+func (mat *DOKMatrix) InsertColumns(j, count int) SparseMat {
+	if j < 0 || j > mat.cols {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", j, mat.cols))
+	}
+
+	mat.remap(mat.rows, mat.cols+count, identityIndex, insertionIndex(j, count))
+	return mat
+}
+
+// DeleteColumns removes the given columns from the matrix.
+func (mat *DOKMatrix) DeleteColumns(cols ...int) SparseMat {
+	newIndex, newCols := deletionMap(mat.cols, cols)
+
+	mat.remap(mat.rows, newCols, identityIndex, func(j int) int { return newIndex[j] })
+	return mat
+}
+
+// Resize changes the shape of the matrix to rows x cols, new rows and columns
+// are zero and the values outside of the new shape are dropped.
+func (mat *DOKMatrix) Resize(rows, cols int) SparseMat {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("resize rows and cols must be >= 0 found (%v,%v)", rows, cols))
+	}
+
+	mat.remap(rows, cols, truncationIndex(rows), truncationIndex(cols))
+	return mat
+}
+
+// SelectRows returns a new matrix made of the rows of this matrix given by idx, in
+// that order. Rows may be selected more than once.
+func (mat *DOKMatrix) SelectRows(idx []int) SparseMat {
+	m := dokMat(len(idx), mat.cols)
+	for k, i := range idx {
+		mat.checkRowBounds(i)
+		for j := range mat.rowValues[i] {
+			m.set(k, j, 1)
+		}
+	}
+	return m
+}
+
+// SelectColumns returns a new matrix made of the columns of this matrix given by
+// idx, in that order. Columns may be selected more than once.
+func (mat *DOKMatrix) SelectColumns(idx []int) SparseMat {
+	m := dokMat(mat.rows, len(idx))
+	for k, j := range idx {
+		mat.checkColBounds(j)
+		for i := range mat.colValues[j] {
+			m.set(i, k, 1)
+		}
+	}
+	return m
+}
+
+// SelectRowsMask returns a new matrix made of the rows of this matrix where mask,
+// whose length must be the number of rows, is 1.
+func (mat *DOKMatrix) SelectRowsMask(mask SparseVector) SparseMat {
+	if mat.rows != mask.Len() {
+		panic("matrix number of rows must equal length of mask")
+	}
+	return mat.SelectRows(mask.NonzeroArray())
+}
+
+// SelectColumnsMask returns a new matrix made of the columns of this matrix where
+// mask, whose length must be the number of columns, is 1.
+func (mat *DOKMatrix) SelectColumnsMask(mask SparseVector) SparseMat {
+	if mat.cols != mask.Len() {
+		panic("matrix number of columns must equal length of mask")
+	}
+	return mat.SelectColumns(mask.NonzeroArray())
+}
+
+// SetRows sets row idx[k] of this matrix to row k of a, for every k. The indices
+// must be unique and a must have len(idx) rows and as many columns as this matrix.
+func (mat *DOKMatrix) SetRows(idx []int, a SparseMat) SparseMat {
+	checkScatter(idx, mat.rows, a, len(idx), mat.cols)
+
+	for k, i := range idx {
+		for j := range mat.rowValues[i] {
+			mat.set(i, j, 0)
+		}
+		for _, j := range a.Row(k).NonzeroArray() {
+			mat.set(i, j, 1)
+		}
+	}
+	return mat
+}
+
+// SetColumns sets column idx[k] of this matrix to column k of a, for every k. The
+// indices must be unique and a must have len(idx) columns and as many rows as this matrix.
+func (mat *DOKMatrix) SetColumns(idx []int, a SparseMat) SparseMat {
+	checkScatter(idx, mat.cols, a, mat.rows, len(idx))
+
+	for k, j := range idx {
+		for i := range mat.colValues[j] {
+			mat.set(i, j, 0)
+		}
+		for _, i := range a.Column(k).NonzeroArray() {
+			mat.set(i, j, 1)
+		}
+	}
+	return mat
+}
+
+// PermuteRows moves row i of this matrix to row p[i], which is the product P*M
+// with P the matrix of p.
+func (mat *DOKMatrix) PermuteRows(p Permutation) SparseMat {
+	p.check(mat.rows)
+
+	mat.remap(mat.rows, mat.cols, func(i int) int { return p[i] }, identityIndex)
+	return mat
+}
+
+// PermuteColumns moves column j of this matrix to column p[j], which is the
+// product M*P^T with P the matrix of p.
+func (mat *DOKMatrix) PermuteColumns(p Permutation) SparseMat {
+	p.check(mat.cols)
+
+	mat.remap(mat.rows, mat.cols, identityIndex, func(j int) int { return p[j] })
+	return mat
+}
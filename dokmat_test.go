@@ -663,3 +663,243 @@ func BenchmarkDOKMatrix_T(b *testing.B) {
 //		})
 //	}
 //}
+
+func TestDOKMatrix_AppendRow(t *testing.T) {
+	m := DOKMat(0, 3)
+	m.AppendRow(DOKVec(3, 1, 0, 1))
+	m.AppendRow(DOKVec(3, 0, 1, 0))
+
+	expected := DOKMat(2, 3, 1, 0, 1, 0, 1, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestDOKMatrix_InsertRows(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		i, count int
+		expected SparseMat
+	}{
+		{DOKMat(2, 2, 1, 0, 0, 1), 0, 1, DOKMat(3, 2, 0, 0, 1, 0, 0, 1)},
+		{DOKMat(2, 2, 1, 0, 0, 1), 1, 2, DOKMat(4, 2, 1, 0, 0, 0, 0, 0, 0, 1)},
+		{DOKMat(2, 2, 1, 0, 0, 1), 2, 1, DOKMat(3, 2, 1, 0, 0, 1, 0, 0)},
+		{DOKMat(2, 2, 1, 0, 0, 1), 1, 0, DOKMat(2, 2, 1, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.InsertRows(test.i, test.count)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_DeleteRows(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		rows     []int
+		expected SparseMat
+	}{
+		{DOKMat(3, 2, 1, 0, 0, 1, 1, 1), []int{1}, DOKMat(2, 2, 1, 0, 1, 1)},
+		{DOKMat(3, 2, 1, 0, 0, 1, 1, 1), []int{2, 0}, DOKMat(1, 2, 0, 1)},
+		{DOKMat(3, 2, 1, 0, 0, 1, 1, 1), []int{}, DOKMat(3, 2, 1, 0, 0, 1, 1, 1)},
+		{DOKMat(3, 2, 1, 0, 0, 1, 1, 1), []int{0, 1, 2}, DOKMat(0, 2)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.DeleteRows(test.rows...)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_InsertColumns(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		j, count int
+		expected SparseMat
+	}{
+		{DOKMat(2, 2, 1, 0, 1, 1), 0, 1, DOKMat(2, 3, 0, 1, 0, 0, 1, 1)},
+		{DOKMat(2, 2, 1, 0, 1, 1), 1, 2, DOKMat(2, 4, 1, 0, 0, 0, 1, 0, 0, 1)},
+		{DOKMat(2, 2, 1, 0, 1, 1), 2, 1, DOKMat(2, 3, 1, 0, 0, 1, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.InsertColumns(test.j, test.count)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_DeleteColumns(t *testing.T) {
+	tests := []struct {
+		input    SparseMat
+		cols     []int
+		expected SparseMat
+	}{
+		{DOKMat(2, 3, 1, 0, 1, 0, 1, 1), []int{1}, DOKMat(2, 2, 1, 1, 0, 1)},
+		{DOKMat(2, 3, 1, 0, 1, 0, 1, 1), []int{2, 0}, DOKMat(2, 1, 0, 1)},
+		{DOKMat(2, 3, 1, 0, 1, 0, 1, 1), []int{}, DOKMat(2, 3, 1, 0, 1, 0, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.DeleteColumns(test.cols...)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_DeleteColumns_duplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a duplicate column")
+		}
+	}()
+	DOKIdentity(3).DeleteColumns(1, 1)
+}
+
+func TestDOKMatrix_Resize(t *testing.T) {
+	tests := []struct {
+		input      SparseMat
+		rows, cols int
+		expected   SparseMat
+	}{
+		{DOKIdentity(2), 3, 3, DOKMat(3, 3, 1, 0, 0, 0, 1, 0, 0, 0, 0)},
+		{DOKIdentity(3), 2, 2, DOKIdentity(2)},
+		{DOKIdentity(3), 1, 3, DOKMat(1, 3, 1, 0, 0)},
+		{DOKIdentity(3), 0, 0, DOKMat(0, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Resize(test.rows, test.cols)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_SelectRows(t *testing.T) {
+	m := DOKMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+	tests := []struct {
+		idx      []int
+		expected SparseMat
+	}{
+		{[]int{2, 0}, DOKMat(2, 3, 1, 0, 1, 1, 0, 0)},
+		{[]int{1, 1}, DOKMat(2, 3, 0, 1, 1, 0, 1, 1)},
+		{[]int{}, DOKMat(0, 3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := m.SelectRows(test.idx)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_SelectColumns(t *testing.T) {
+	m := DOKMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+	tests := []struct {
+		idx      []int
+		expected SparseMat
+	}{
+		{[]int{2, 0}, DOKMat(3, 2, 0, 1, 1, 0, 1, 1)},
+		{[]int{0, 0, 1}, DOKMat(3, 3, 1, 1, 0, 0, 0, 1, 1, 1, 0)},
+		{[]int{}, DOKMat(3, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := m.SelectColumns(test.idx)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDOKMatrix_SelectMask(t *testing.T) {
+	m := DOKMat(3, 3, 1, 0, 0, 0, 1, 1, 1, 0, 1)
+
+	expected := DOKMat(2, 3, 1, 0, 0, 1, 0, 1)
+	if actual := m.SelectRowsMask(DOKVec(3, 1, 0, 1)); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+
+	expected = DOKMat(3, 2, 0, 0, 1, 1, 0, 1)
+	if actual := m.SelectColumnsMask(DOKVec(3, 0, 1, 1)); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestDOKMatrix_SetRows(t *testing.T) {
+	m := DOKIdentity(3)
+	m.SetRows([]int{2, 0}, DOKMat(2, 3, 1, 1, 0, 0, 0, 0))
+
+	expected := DOKMat(3, 3, 0, 0, 0, 0, 1, 0, 1, 1, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestDOKMatrix_SetColumns(t *testing.T) {
+	m := DOKIdentity(3)
+	m.SetColumns([]int{2, 0}, DOKMat(3, 2, 1, 0, 1, 0, 0, 0))
+
+	expected := DOKMat(3, 3, 0, 0, 1, 0, 1, 1, 0, 0, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, m)
+	}
+}
+
+func TestDOKMatrix_SelectSetColumns_random(t *testing.T) {
+	m := DOKMatCopy(randomMatrix(50, 40))
+	idx := rand.Perm(40)[:15]
+
+	sub := m.SelectColumns(idx)
+	for k, j := range idx {
+		if !sub.Column(k).Equals(m.Column(j)) {
+			t.Fatalf("expected column %v to be column %v", k, j)
+		}
+	}
+
+	cp := DOKMatCopy(m)
+	cp.SetColumns(idx, DOKMat(50, 15))
+	cp.SetColumns(idx, sub)
+	if !cp.Equals(m) {
+		t.Fatalf("expected %v but found %v", m, cp)
+	}
+}
+
+func TestDOKMatrix_PermuteRows(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	m := DOKMatCopy(randomMatrix(12, 9))
+	p := RandomPermutation(12, r)
+
+	expected := DOKMat(12, 9)
+	expected.Mul(p.Matrix(), m)
+	if actual := m.PermuteRows(p); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestDOKMatrix_PermuteColumns(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	m := DOKMatCopy(randomMatrix(12, 9))
+	p := RandomPermutation(9, r)
+
+	expected := DOKMat(12, 9)
+	expected.Mul(m, p.Matrix().T())
+	if actual := m.PermuteColumns(p); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
@@ -33,11 +33,45 @@ func (vec *DOKVector) UnmarshalJSON(bytes []byte) error {
 	if err != nil {
 		return err
 	}
+
+	if v.Length < 0 {
+		return fmt.Errorf("invalid vector length %v", v.Length)
+	}
+	if v.Values == nil {
+		v.Values = make(map[int]int)
+	}
+	for i, value := range v.Values {
+		if i < 0 || i >= v.Length {
+			return fmt.Errorf("index %v out of range: [0-%v]", i, v.Length-1)
+		}
+		if value != 1 {
+			return fmt.Errorf("index %v has value %v, only 1's are stored", i, value)
+		}
+	}
 	vec.length = v.Length
 	vec.values = v.Values
 	return nil
 }
 
+// MarshalBinary encodes the vector in the compact binary layout also used by gob.
+func (vec *DOKVector) MarshalBinary() ([]byte, error) {
+	return marshalVector(binaryDOKVector, vec), nil
+}
+
+// UnmarshalBinary decodes a vector written by MarshalBinary of any vector type.
+func (vec *DOKVector) UnmarshalBinary(data []byte) error {
+	length, indices, err := unmarshalVector(data)
+	if err != nil {
+		return err
+	}
+
+	*vec = *dokVec(length)
+	for _, i := range indices {
+		vec.values[i] = 1
+	}
+	return nil
+}
+
 func DOKVec(length int, values ...int) SparseVector {
 	if len(values) != 0 {
 		if length != len(values) {
@@ -376,3 +410,162 @@ func (vec *DOKVector) NextSet(startingIndex int) (index int, has bool) {
 	}
 	return -1, false
 }
+
+// Append extends the vector with the values of a.
+func (vec *DOKVector) Append(a SparseVector) SparseVector {
+	for _, i := range a.NonzeroArray() {
+		vec.values[vec.length+i] = 1
+	}
+	vec.length += a.Len()
+	return vec
+}
+
+// Truncate shortens the vector to the given length dropping the values past it.
+func (vec *DOKVector) Truncate(length int) SparseVector {
+	if length < 0 || length > vec.length {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", length, vec.length))
+	}
+
+	for i := range vec.values {
+		if i >= length {
+			delete(vec.values, i)
+		}
+	}
+	vec.length = length
+	return vec
+}
+
+// Permute moves the value at index i of this vector to index p[i].
+func (vec *DOKVector) Permute(p Permutation) SparseVector {
+	p.check(vec.length)
+
+	permuted := make(map[int]int, len(vec.values))
+	for i := range vec.values {
+		permuted[p[i]] = 1
+	}
+	vec.values = permuted
+	return vec
+}
+
+// RotateLeft cyclically moves the value at index i to index (i-k) mod Len().
+func (vec *DOKVector) RotateLeft(k int) SparseVector {
+	return vec.RotateRight(-k)
+}
+
+// RotateRight cyclically moves the value at index i to index (i+k) mod Len().
+func (vec *DOKVector) RotateRight(k int) SparseVector {
+	if vec.length == 0 {
+		return vec
+	}
+
+	k = ((k % vec.length) + vec.length) % vec.length
+	rotated := make(map[int]int, len(vec.values))
+	for i := range vec.values {
+		rotated[(i+k)%vec.length] = 1
+	}
+	vec.values = rotated
+	return vec
+}
+
+// ShiftLeft moves the value at index i to index i-k, values shifted past index 0
+// are dropped and the vacated indices are set to 0.
+func (vec *DOKVector) ShiftLeft(k int) SparseVector {
+	checkShift(k)
+
+	shifted := make(map[int]int, len(vec.values))
+	for i := range vec.values {
+		if i >= k {
+			shifted[i-k] = 1
+		}
+	}
+	vec.values = shifted
+	return vec
+}
+
+// ShiftRight moves the value at index i to index i+k, values shifted past the end
+// are dropped and the vacated indices are set to 0.
+func (vec *DOKVector) ShiftRight(k int) SparseVector {
+	checkShift(k)
+
+	shifted := make(map[int]int, len(vec.values))
+	for i := range vec.values {
+		if i < vec.length-k {
+			shifted[i+k] = 1
+		}
+	}
+	vec.values = shifted
+	return vec
+}
+
+// Reverse reverses the order of the values, moving index i to Len()-1-i.
+func (vec *DOKVector) Reverse() SparseVector {
+	reversed := make(map[int]int, len(vec.values))
+	for i := range vec.values {
+		reversed[vec.length-1-i] = 1
+	}
+	vec.values = reversed
+	return vec
+}
+
+// Rank returns the number of ones at the indices before i, i can be Len() to count
+// all the ones.
+func (vec *DOKVector) Rank(i int) int {
+	if i < 0 || i > vec.length {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, vec.length))
+	}
+
+	count := 0
+	for j := range vec.values {
+		if j < i {
+			count++
+		}
+	}
+	return count
+}
+
+// Select returns the index of the k-th one, counting from 0. If the vector has k
+// or fewer ones has bool will be set to false.
+func (vec *DOKVector) Select(k int) (index int, has bool) {
+	if k < 0 || k >= len(vec.values) {
+		return -1, false
+	}
+	return vec.NonzeroArray()[k], true
+}
+
+// PrevSet returns the previous bit which is set starting from startingIndex, so if
+// the startingIndex is set it will be returned, if not it will be the previous bit.
+// If no bits are found has bool will be set to false.
+func (vec *DOKVector) PrevSet(startingIndex int) (index int, has bool) {
+	vec.checkBounds(startingIndex)
+
+	for i := startingIndex; i >= 0; i-- {
+		if vec.at(i) > 0 {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// FirstSet returns the lowest index which is set, if none are has bool will be set
+// to false.
+func (vec *DOKVector) FirstSet() (index int, has bool) {
+	index = -1
+	for i := range vec.values {
+		if index < 0 || i < index {
+			index = i
+		}
+	}
+	return index, index >= 0
+}
+
+// LastSet returns the highest index which is set, if none are has bool will be set
+// to false.
+func (vec *DOKVector) LastSet() (index int, has bool) {
+	index = -1
+	for i := range vec.values {
+		if i > index {
+			index = i
+		}
+	}
+	return index, index >= 0
+}
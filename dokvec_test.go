@@ -381,3 +381,232 @@ func TestDOKVector_NextSet(t *testing.T) {
 		})
 	}
 }
+
+func TestDOKVector_Append(t *testing.T) {
+	tests := []struct {
+		input, a SparseVector
+		expected SparseVector
+	}{
+		{DOKVec(2, 1, 0), DOKVec(3, 0, 1, 1), DOKVec(5, 1, 0, 0, 1, 1)},
+		{DOKVec(0), CSRVec(2, 1, 0), DOKVec(2, 1, 0)},
+		{DOKVec(2, 0, 1), DOKVec(0), DOKVec(2, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Append(test.a)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Truncate(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		length   int
+		expected SparseVector
+	}{
+		{DOKVec(5, 1, 0, 0, 1, 1), 3, DOKVec(3, 1, 0, 0)},
+		{DOKVec(5, 1, 0, 0, 1, 1), 5, DOKVec(5, 1, 0, 0, 1, 1)},
+		{DOKVec(5, 1, 0, 0, 1, 1), 0, DOKVec(0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Truncate(test.length)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Permute(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		p        Permutation
+		expected SparseVector
+	}{
+		{DOKVec(3, 1, 1, 0), Permutation{2, 0, 1}, DOKVec(3, 1, 0, 1)},
+		{DOKVec(4, 1, 0, 0, 1), Permutation{3, 2, 1, 0}, DOKVec(4, 1, 0, 0, 1)},
+		{DOKVec(4, 1, 0, 0, 0), Permutation{3, 2, 1, 0}, DOKVec(4, 0, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Permute(test.p)
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Rotate(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		k        int
+		expected SparseVector
+	}{
+		{DOKVec(5, 1, 1, 0, 0, 0), 1, DOKVec(5, 0, 1, 1, 0, 0)},
+		{DOKVec(5, 1, 0, 0, 0, 1), 2, DOKVec(5, 0, 1, 1, 0, 0)},
+		{DOKVec(5, 1, 0, 1, 0, 1), 5, DOKVec(5, 1, 0, 1, 0, 1)},
+		{DOKVec(5, 1, 0, 1, 0, 1), -1, DOKVec(5, 0, 1, 0, 1, 1)},
+		{DOKVec(5, 1, 0, 1, 0, 1), 12, DOKVec(5, 0, 1, 1, 0, 1)},
+		{DOKVec(0), 3, DOKVec(0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := DOKVecCopy(test.input).RotateRight(test.k)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			actual.RotateLeft(test.k)
+			if !actual.Equals(test.input) {
+				t.Fatalf("expected %v but found %v", test.input, actual)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Shift(t *testing.T) {
+	tests := []struct {
+		input       SparseVector
+		k           int
+		left, right SparseVector
+	}{
+		{DOKVec(5, 1, 1, 0, 0, 1), 0, DOKVec(5, 1, 1, 0, 0, 1), DOKVec(5, 1, 1, 0, 0, 1)},
+		{DOKVec(5, 1, 1, 0, 0, 1), 1, DOKVec(5, 1, 0, 0, 1, 0), DOKVec(5, 0, 1, 1, 0, 0)},
+		{DOKVec(5, 1, 1, 0, 0, 1), 4, DOKVec(5, 1, 0, 0, 0, 0), DOKVec(5, 0, 0, 0, 0, 1)},
+		{DOKVec(5, 1, 1, 0, 0, 1), 7, DOKVec(5), DOKVec(5)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := DOKVecCopy(test.input).ShiftLeft(test.k); !actual.Equals(test.left) {
+				t.Fatalf("expected %v but found %v", test.left, actual)
+			}
+			if actual := DOKVecCopy(test.input).ShiftRight(test.k); !actual.Equals(test.right) {
+				t.Fatalf("expected %v but found %v", test.right, actual)
+			}
+		})
+	}
+}
+
+func TestDOKVector_ShiftPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	DOKVec(5).ShiftLeft(-1)
+}
+
+func TestDOKVector_Reverse(t *testing.T) {
+	tests := []struct {
+		input    SparseVector
+		expected SparseVector
+	}{
+		{DOKVec(5, 1, 1, 0, 0, 0), DOKVec(5, 0, 0, 0, 1, 1)},
+		{DOKVec(4, 1, 0, 1, 1), DOKVec(4, 1, 1, 0, 1)},
+		{DOKVec(3), DOKVec(3)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.input.Reverse()
+			if !test.input.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, test.input)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Rank(t *testing.T) {
+	vec := DOKVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		i, expected int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 1},
+		{3, 2},
+		{5, 2},
+		{6, 3},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := vec.Rank(test.i); actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDOKVector_Select(t *testing.T) {
+	vec := DOKVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		k        int
+		index    int
+		expected bool
+	}{
+		{0, 1, true},
+		{1, 2, true},
+		{2, 5, true},
+		{3, -1, false},
+		{-1, -1, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			index, has := vec.Select(test.k)
+			if index != test.index || has != test.expected {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.index, test.expected, index, has)
+			}
+			if has && vec.Rank(index) != test.k {
+				t.Fatalf("expected rank %v but found %v", test.k, vec.Rank(index))
+			}
+		})
+	}
+}
+
+func TestDOKVector_PrevSet(t *testing.T) {
+	vec := DOKVec(6, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		start    int
+		index    int
+		expected bool
+	}{
+		{0, -1, false},
+		{1, 1, true},
+		{2, 2, true},
+		{4, 2, true},
+		{5, 5, true},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			index, has := vec.PrevSet(test.start)
+			if index != test.index || has != test.expected {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.index, test.expected, index, has)
+			}
+		})
+	}
+}
+
+func TestDOKVector_FirstLastSet(t *testing.T) {
+	tests := []struct {
+		vec         SparseVector
+		first, last int
+		has         bool
+	}{
+		{DOKVec(6, 0, 1, 1, 0, 0, 1), 1, 5, true},
+		{DOKVec(3, 1, 0, 0), 0, 0, true},
+		{DOKVec(3), -1, -1, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if index, has := test.vec.FirstSet(); index != test.first || has != test.has {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.first, test.has, index, has)
+			}
+			if index, has := test.vec.LastSet(); index != test.last || has != test.has {
+				t.Fatalf("expected (%v,%v) but found (%v,%v)", test.last, test.has, index, has)
+			}
+		})
+	}
+}
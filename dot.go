@@ -0,0 +1,109 @@
+package sparsemat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// DOTOptions controls the graphs written by WriteTannerDOT and WriteAdjacencyDOT.
+// Highlighted nodes, and the edges between two highlighted nodes, are drawn in red.
+type DOTOptions struct {
+	// HighlightRows are the rows (check nodes) to highlight.
+	HighlightRows []int
+	// HighlightColumns are the columns (variable nodes) to highlight.
+	HighlightColumns []int
+}
+
+const dotHighlight = ` [color=red, penwidth=2]`
+
+// highlightSet returns the highlighted indices as a set and panics
+// if any of them is outside [0, length).
+func highlightSet(indices []int, length int) map[int]bool {
+	set := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		if i < 0 || length <= i {
+			panic(fmt.Sprintf("%v out of range: [0-%v]", i, length-1))
+		}
+		set[i] = true
+	}
+	return set
+}
+
+func (opts *DOTOptions) sets(rows, cols int) (hRows, hCols map[int]bool) {
+	if opts == nil {
+		return map[int]bool{}, map[int]bool{}
+	}
+	return highlightSet(opts.HighlightRows, rows), highlightSet(opts.HighlightColumns, cols)
+}
+
+// WriteTannerDOT writes m to w in the Graphviz DOT language as the bipartite
+// Tanner graph of the parity-check matrix m. Column j is the variable node vj
+// (drawn as a circle) and row i is the check node ci (drawn as a box), with an
+// edge between them when m[i][j] is 1. opts may be nil.
+func WriteTannerDOT(w io.Writer, m SparseMat, opts *DOTOptions) error {
+	rows, cols := m.Dims()
+	hRows, hCols := opts.sets(rows, cols)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "graph tanner {")
+	for j := 0; j < cols; j++ {
+		fmt.Fprintf(bw, "\tv%v [shape=circle]", j)
+		if hCols[j] {
+			bw.WriteString(dotHighlight)
+		}
+		bw.WriteString(";\n")
+	}
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(bw, "\tc%v [shape=box]", i)
+		if hRows[i] {
+			bw.WriteString(dotHighlight)
+		}
+		bw.WriteString(";\n")
+	}
+	for i := 0; i < rows; i++ {
+		for _, j := range m.Row(i).NonzeroArray() {
+			fmt.Fprintf(bw, "\tc%v -- v%v", i, j)
+			if hRows[i] && hCols[j] {
+				bw.WriteString(dotHighlight)
+			}
+			bw.WriteString(";\n")
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteAdjacencyDOT writes the square matrix m to w in the Graphviz DOT language
+// as a directed graph with an edge from node i to node j when m[i][j] is 1. Since
+// node i is both row i and column i, a node is highlighted when it is listed in
+// either HighlightRows or HighlightColumns. opts may be nil.
+func WriteAdjacencyDOT(w io.Writer, m SparseMat, opts *DOTOptions) error {
+	rows, cols := m.Dims()
+	if rows != cols {
+		panic(fmt.Sprintf("adjacency matrix must be square found (%v,%v)", rows, cols))
+	}
+	hRows, hCols := opts.sets(rows, cols)
+	highlighted := func(i int) bool { return hRows[i] || hCols[i] }
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph adjacency {")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(bw, "\t%v", i)
+		if highlighted(i) {
+			bw.WriteString(dotHighlight)
+		}
+		bw.WriteString(";\n")
+	}
+	for i := 0; i < rows; i++ {
+		for _, j := range m.Row(i).NonzeroArray() {
+			fmt.Fprintf(bw, "\t%v -> %v", i, j)
+			if highlighted(i) && highlighted(j) {
+				bw.WriteString(dotHighlight)
+			}
+			bw.WriteString(";\n")
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
@@ -0,0 +1,77 @@
+package sparsemat
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+)
+
+func TestWriteTannerDOT(t *testing.T) {
+	tests := []struct {
+		m        SparseMat
+		opts     *DOTOptions
+		expected string
+	}{
+		{CSRMat(1, 2, 1, 1), nil, "graph tanner {\n" +
+			"\tv0 [shape=circle];\n" +
+			"\tv1 [shape=circle];\n" +
+			"\tc0 [shape=box];\n" +
+			"\tc0 -- v0;\n" +
+			"\tc0 -- v1;\n" +
+			"}\n"},
+		{DOKMat(2, 2, 1, 1, 0, 1), &DOTOptions{HighlightRows: []int{0}, HighlightColumns: []int{1}}, "graph tanner {\n" +
+			"\tv0 [shape=circle];\n" +
+			"\tv1 [shape=circle] [color=red, penwidth=2];\n" +
+			"\tc0 [shape=box] [color=red, penwidth=2];\n" +
+			"\tc1 [shape=box];\n" +
+			"\tc0 -- v0;\n" +
+			"\tc0 -- v1 [color=red, penwidth=2];\n" +
+			"\tc1 -- v1;\n" +
+			"}\n"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := WriteTannerDOT(buff, test.m, test.opts); err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != test.expected {
+				t.Fatalf("expected\n%v\nbut found\n%v", test.expected, buff.String())
+			}
+		})
+	}
+}
+
+func TestWriteAdjacencyDOT(t *testing.T) {
+	tests := []struct {
+		m        SparseMat
+		opts     *DOTOptions
+		expected string
+	}{
+		{CSRMat(2, 2, 0, 1, 1, 0), nil, "digraph adjacency {\n" +
+			"\t0;\n" +
+			"\t1;\n" +
+			"\t0 -> 1;\n" +
+			"\t1 -> 0;\n" +
+			"}\n"},
+		{CSRMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0), &DOTOptions{HighlightRows: []int{0}, HighlightColumns: []int{1}}, "digraph adjacency {\n" +
+			"\t0 [color=red, penwidth=2];\n" +
+			"\t1 [color=red, penwidth=2];\n" +
+			"\t2;\n" +
+			"\t0 -> 1 [color=red, penwidth=2];\n" +
+			"\t1 -> 2;\n" +
+			"\t2 -> 0;\n" +
+			"}\n"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := WriteAdjacencyDOT(buff, test.m, test.opts); err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != test.expected {
+				t.Fatalf("expected\n%v\nbut found\n%v", test.expected, buff.String())
+			}
+		})
+	}
+}
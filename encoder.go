@@ -0,0 +1,302 @@
+package sparsemat
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Encoder maps messages to codewords of a fixed code. It is built once from a
+// parity-check or generator matrix and can then encode any number of messages.
+// The encoding is systematic, bit i of a message is found at codeword position
+// InfoPositions()[i].
+type Encoder struct {
+	n, k int
+	info []int
+
+	// generator holds the systematic generator rows, it is only set
+	// for encoders created by NewGeneratorEncoder.
+	generator *CSRMatrix
+
+	// for parity-check encoders the parity bits are split into triangular
+	// bits solved by substitution in the order of triRows/triCols and gap
+	// bits solved through the dense inverse of the gap matrix phi.
+	checks    [][]int
+	triRows   []int
+	triCols   []int
+	gapChecks [][]int
+	gapCols   []int
+	phiInv    [][]int
+}
+
+// NewEncoder creates an encoder for the code with parity-check matrix h using the
+// Richardson-Urbanke method. The columns and rows of h are greedily arranged into
+// an approximate lower triangular form so most parity bits are found by sparse
+// back-substitution, only the remaining gap bits need a small dense matrix. For
+// low density parity-check matrices the gap is usually small, making encoding close
+// to linear in the number of ones of h. Redundant rows of h are allowed.
+func NewEncoder(h SparseMat) *Encoder {
+	rows, n := h.Dims()
+
+	checks := make([][]int, rows)
+	for i := range checks {
+		checks[i] = h.Row(i).NonzeroArray()
+	}
+
+	enc := &Encoder{
+		n:      n,
+		checks: checks,
+	}
+	enc.triangulate()
+	enc.solveGap()
+
+	return enc
+}
+
+// NewGeneratorEncoder creates an encoder for the code spanned by the rows of the
+// generator matrix g. The generator is brought into systematic form once, after
+// which encoding adds up the sparse rows selected by the message.
+func NewGeneratorEncoder(g SparseMat) *Encoder {
+	_, n := g.Dims()
+	reduced, pivots := rowReduce(g, nil)
+
+	return &Encoder{
+		n:         n,
+		k:         len(pivots),
+		info:      pivots,
+		generator: reduced,
+	}
+}
+
+// triangulate greedily picks for as many checks as possible a parity bit that
+// can be found from bits already known. When no check has a single unknown bit
+// all but one unknown bit of the lightest check are made free.
+func (enc *Encoder) triangulate() {
+	colChecks := make([][]int, enc.n)
+	degree := make([]int, len(enc.checks))
+	queue := make([]int, 0)
+	for r, row := range enc.checks {
+		for _, c := range row {
+			colChecks[c] = append(colChecks[c], r)
+		}
+		degree[r] = len(row)
+		if degree[r] == 1 {
+			queue = append(queue, r)
+		}
+	}
+
+	known := make([]bool, enc.n)
+	done := make([]bool, len(enc.checks))
+	markKnown := func(c int) {
+		known[c] = true
+		for _, r := range colChecks[c] {
+			degree[r]--
+			if !done[r] && degree[r] == 1 {
+				queue = append(queue, r)
+			}
+		}
+	}
+
+	for {
+		for len(queue) > 0 {
+			r := queue[0]
+			queue = queue[1:]
+			if done[r] || degree[r] != 1 {
+				continue
+			}
+			for _, c := range enc.checks[r] {
+				if !known[c] {
+					done[r] = true
+					enc.triRows = append(enc.triRows, r)
+					enc.triCols = append(enc.triCols, c)
+					markKnown(c)
+					break
+				}
+			}
+		}
+
+		lightest := -1
+		for r := range enc.checks {
+			if !done[r] && degree[r] > 0 && (lightest < 0 || degree[r] < degree[lightest]) {
+				lightest = r
+			}
+		}
+		if lightest < 0 {
+			break
+		}
+
+		unknown := make([]int, 0, degree[lightest])
+		for _, c := range enc.checks[lightest] {
+			if !known[c] {
+				unknown = append(unknown, c)
+			}
+		}
+		for _, c := range unknown[:len(unknown)-1] {
+			markKnown(c)
+		}
+	}
+}
+
+// solveGap eliminates the triangular bits from the remaining checks, chooses the
+// gap bits among the free bits and inverts the resulting dense gap matrix.
+func (enc *Encoder) solveGap() {
+	isTri := make([]bool, enc.n)
+	for _, c := range enc.triCols {
+		isTri[c] = true
+	}
+	free := make([]int, 0, enc.n-len(enc.triCols))
+	for c := 0; c < enc.n; c++ {
+		if !isTri[c] {
+			free = append(free, c)
+		}
+	}
+
+	inTri := make([]bool, len(enc.checks))
+	for _, r := range enc.triRows {
+		inTri[r] = true
+	}
+
+	acc := make([]bool, enc.n)
+	gapChecks := make([][]int, 0)
+	for r, row := range enc.checks {
+		if inTri[r] {
+			continue
+		}
+
+		for _, c := range row {
+			acc[c] = !acc[c]
+		}
+		for i := len(enc.triRows) - 1; i >= 0; i-- {
+			if acc[enc.triCols[i]] {
+				for _, c := range enc.checks[enc.triRows[i]] {
+					acc[c] = !acc[c]
+				}
+			}
+		}
+
+		gap := make([]int, 0)
+		for _, c := range free {
+			if acc[c] {
+				gap = append(gap, c)
+				acc[c] = false
+			}
+		}
+		gapChecks = append(gapChecks, gap)
+	}
+
+	// the triangular checks are independent so any redundant
+	// checks of h show up as dependent gap checks
+	independent := independentRows(csrMatFromIndices(len(gapChecks), enc.n, gapChecks))
+	g := len(independent)
+	for i, r := range independent {
+		gapChecks[i] = gapChecks[r]
+	}
+	gapChecks = gapChecks[:g]
+
+	gapMat := csrMatFromIndices(g, enc.n, gapChecks)
+	_, pivots := rowReduce(gapMat, free)
+
+	// invert phi by row reducing [phi | I]
+	augmented := csrMat(g, 2*g)
+	for i := 0; i < g; i++ {
+		for j, c := range pivots {
+			if gapMat.at(i, c) == 1 {
+				augmented.data[i] = append(augmented.data[i], j)
+			}
+		}
+		augmented.data[i] = append(augmented.data[i], g+i)
+	}
+	inverse, _ := rowReduce(augmented, nil)
+
+	enc.phiInv = make([][]int, g)
+	for i := range enc.phiInv {
+		row := inverse.data[i]
+		enc.phiInv[i] = make([]int, 0, len(row))
+		for _, j := range row[findIndex(row, g):] {
+			enc.phiInv[i] = append(enc.phiInv[i], j-g)
+		}
+	}
+
+	isGap := make(map[int]bool, g)
+	for _, c := range pivots {
+		isGap[c] = true
+	}
+	enc.k = len(free) - g
+	enc.info = make([]int, 0, enc.k)
+	for _, c := range free {
+		if !isGap[c] {
+			enc.info = append(enc.info, c)
+		}
+	}
+	sort.Ints(enc.info)
+
+	enc.gapChecks = gapChecks
+	enc.gapCols = pivots
+}
+
+// Dims returns the dimension k and the length n of the code.
+func (enc *Encoder) Dims() (k, n int) {
+	return enc.k, enc.n
+}
+
+// InfoPositions returns the codeword positions that hold the message bits.
+func (enc *Encoder) InfoPositions() []int {
+	info := make([]int, len(enc.info))
+	copy(info, enc.info)
+	return info
+}
+
+// Encode returns the codeword for the message msg, which must have length k.
+func (enc *Encoder) Encode(msg SparseVector) SparseVector {
+	if msg.Len() != enc.k {
+		panic(fmt.Sprintf("message length %v does not match code dimension %v", msg.Len(), enc.k))
+	}
+
+	if enc.generator != nil {
+		codeword := make([]int, 0)
+		for _, i := range msg.NonzeroArray() {
+			codeword = addRows(codeword, enc.generator.data[i])
+		}
+		return &CSRVector{length: enc.n, indices: codeword}
+	}
+
+	x := make([]bool, enc.n)
+	for _, i := range msg.NonzeroArray() {
+		x[enc.info[i]] = true
+	}
+
+	// the gap bits must cancel what the message contributes to the gap checks
+	syndrome := make([]bool, len(enc.gapChecks))
+	for j, check := range enc.gapChecks {
+		for _, c := range check {
+			if x[c] {
+				syndrome[j] = !syndrome[j]
+			}
+		}
+	}
+	for i, row := range enc.phiInv {
+		for _, j := range row {
+			if syndrome[j] {
+				x[enc.gapCols[i]] = !x[enc.gapCols[i]]
+			}
+		}
+	}
+
+	// every triangular check has a single bit left to solve
+	for i, r := range enc.triRows {
+		parity := false
+		for _, c := range enc.checks[r] {
+			if c != enc.triCols[i] && x[c] {
+				parity = !parity
+			}
+		}
+		x[enc.triCols[i]] = parity
+	}
+
+	indices := make([]int, 0)
+	for c, v := range x {
+		if v {
+			indices = append(indices, c)
+		}
+	}
+	return &CSRVector{length: enc.n, indices: indices}
+}
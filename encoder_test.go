@@ -0,0 +1,91 @@
+package sparsemat
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// randomLDPC creates a parity-check matrix with column weight colWeight.
+func randomLDPC(rows, cols, colWeight int) SparseMat {
+	h := CSRMat(rows, cols)
+	for j := 0; j < cols; j++ {
+		for _, i := range rand.Perm(rows)[:colWeight] {
+			h.Set(i, j, 1)
+		}
+	}
+	return h
+}
+
+func TestEncoder(t *testing.T) {
+	hg, hh := HammingCode(3)
+	gg, _ := GolayCode()
+	tests := []struct {
+		enc *Encoder
+		h   SparseMat
+		k   int
+	}{
+		{NewEncoder(hh), hh, 4},
+		{NewEncoder(DOKMatCopy(hh)), hh, 4},
+		{NewGeneratorEncoder(hg), hh, 4},
+		{NewEncoder(gg), gg, 12},
+		{NewGeneratorEncoder(gg), gg, 12},
+		{NewEncoder(CSRMat(3, 4, 1, 1, 0, 0, 0, 1, 1, 0, 1, 0, 1, 0)), CSRMat(3, 4, 1, 1, 0, 0, 0, 1, 1, 0, 1, 0, 1, 0), 2},
+	}
+	for i := 0; i < 5; i++ {
+		h := randomLDPC(30, 60, 3)
+		tests = append(tests, struct {
+			enc *Encoder
+			h   SparseMat
+			k   int
+		}{NewEncoder(h), h, 60 - len(independentRows(h))})
+	}
+
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			k, n := test.enc.Dims()
+			hRows, hCols := test.h.Dims()
+			if k != test.k || n != hCols {
+				t.Fatalf("expected dims (%v,%v) but found (%v,%v)", test.k, hCols, k, n)
+			}
+
+			info := test.enc.InfoPositions()
+			for trial := 0; trial < 20; trial++ {
+				msg := randomCSRVector(k)
+				c := test.enc.Encode(msg)
+
+				syndrome := CSRVec(hRows)
+				syndrome.MatMul(test.h, c)
+				if !syndrome.IsZero() {
+					t.Fatalf("expected %v to be a codeword", c)
+				}
+				for b, p := range info {
+					if c.At(p) != msg.At(b) {
+						t.Fatalf("expected message bit %v at position %v of %v", b, p, c)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	benchmarks := []struct {
+		rows, cols int
+	}{
+		{50, 100},
+		{500, 1000},
+		{5000, 10000},
+	}
+	for bi, bm := range benchmarks {
+		b.Run(strconv.Itoa(bi), func(b *testing.B) {
+			enc := NewEncoder(randomLDPC(bm.rows, bm.cols, 3))
+			k, _ := enc.Dims()
+			msg := randomCSRVector(k)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				enc.Encode(msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// maxEnumerationDimension bounds the dimension of codes that can be enumerated.
+const maxEnumerationDimension = 62
+
+// codeBasis returns the independent rows spanning the same code as the rows of g.
+func codeBasis(g SparseMat) []SparseVector {
+	reduced, _ := rowReduce(g, nil)
+	if reduced.rows > maxEnumerationDimension {
+		panic(fmt.Sprintf("code dimension %v is too large to enumerate, max is %v", reduced.rows, maxEnumerationDimension))
+	}
+
+	basis := make([]SparseVector, reduced.rows)
+	for i := range basis {
+		basis[i] = reduced.Row(i)
+	}
+	return basis
+}
+
+// WeightDistribution enumerates every codeword of the code spanned by the rows of
+// the generator matrix g and returns the weight enumerator of the code, the value
+// at index w is the number of codewords with Hamming weight w. Codewords are visited
+// in Gray code order so each step costs a single vector addition, still there are
+// 2^k codewords for a code of dimension k so this is only practical for small k.
+func WeightDistribution(g SparseMat) []int {
+	_, n := g.Dims()
+	basis := codeBasis(g)
+
+	distribution := make([]int, n+1)
+	distribution[0] = 1
+
+	c := CSRVec(n)
+	total := uint64(1) << uint(len(basis))
+	for step := uint64(1); step < total; step++ {
+		c.Add(c, basis[bits.TrailingZeros64(step)])
+		distribution[c.HammingWeight()]++
+	}
+	return distribution
+}
+
+// CodewordIterator steps through the codewords of a code with Hamming weight at
+// most a given bound. The codewords are visited in Gray code order starting with
+// the all zero codeword.
+type CodewordIterator struct {
+	basis     []SparseVector
+	maxWeight int
+	codeword  SparseVector
+	step      uint64
+	total     uint64
+}
+
+// NewCodewordIterator creates an iterator over the codewords of the code spanned
+// by the rows of the generator matrix g with Hamming weight at most maxWeight.
+// Call Next to advance to the first codeword.
+func NewCodewordIterator(g SparseMat, maxWeight int) *CodewordIterator {
+	_, n := g.Dims()
+	basis := codeBasis(g)
+
+	return &CodewordIterator{
+		basis:     basis,
+		maxWeight: maxWeight,
+		codeword:  CSRVec(n),
+		total:     uint64(1) << uint(len(basis)),
+	}
+}
+
+// Next advances the iterator to the next codeword within the weight bound and
+// reports whether there was one.
+func (it *CodewordIterator) Next() bool {
+	for it.step < it.total {
+		if it.step > 0 {
+			it.codeword.Add(it.codeword, it.basis[bits.TrailingZeros64(it.step)])
+		}
+		it.step++
+
+		if it.codeword.HammingWeight() <= it.maxWeight {
+			return true
+		}
+	}
+	return false
+}
+
+// Codeword returns a copy of the current codeword.
+func (it *CodewordIterator) Codeword() SparseVector {
+	return CSRVecCopy(it.codeword)
+}
@@ -0,0 +1,65 @@
+package sparsemat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestWeightDistribution(t *testing.T) {
+	tests := []struct {
+		g        SparseMat
+		expected []int
+	}{
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), []int{1, 0, 0, 7, 7, 0, 0, 1}},
+		{DOKMat(1, 3, 1, 1, 1), []int{1, 0, 0, 1}},
+		{CSRMat(3, 3, 1, 1, 0, 0, 1, 1, 1, 0, 1), []int{1, 0, 3, 0}},
+		{CSRMat(2, 2), []int{1, 0, 0}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := WeightDistribution(test.g)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCodewordIterator(t *testing.T) {
+	tests := []struct {
+		g         SparseMat
+		maxWeight int
+		expected  int
+	}{
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 3, 8},
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 7, 16},
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 0, 1},
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), -1, 0},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			h := NullSpace(test.g)
+			hRows, _ := h.Dims()
+			seen := make(map[string]bool)
+
+			it := NewCodewordIterator(test.g, test.maxWeight)
+			for it.Next() {
+				c := it.Codeword()
+				if c.HammingWeight() > test.maxWeight {
+					t.Fatalf("expected weight at most %v but found %v", test.maxWeight, c)
+				}
+				syndrome := CSRVec(hRows)
+				syndrome.MatMul(h, c)
+				if !syndrome.IsZero() {
+					t.Fatalf("expected %v to be a codeword", c)
+				}
+				seen[c.String()] = true
+			}
+
+			if len(seen) != test.expected {
+				t.Fatalf("expected %v distinct codewords but found %v", test.expected, len(seen))
+			}
+		})
+	}
+}
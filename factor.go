@@ -0,0 +1,141 @@
+package sparsemat
+
+import (
+	"math/big"
+	"sort"
+)
+
+var bigOne = big.NewInt(1)
+
+// primeFactors returns the distinct prime factors of n > 0 in increasing order.
+func primeFactors(n *big.Int) []*big.Int {
+	found := make(map[string]*big.Int)
+	addPrimeFactors(new(big.Int).Set(n), found)
+	return sortedFactors(found)
+}
+
+func sortedFactors(found map[string]*big.Int) []*big.Int {
+	primes := make([]*big.Int, 0, len(found))
+	for _, p := range found {
+		primes = append(primes, p)
+	}
+	sort.Slice(primes, func(i, j int) bool { return primes[i].Cmp(primes[j]) < 0 })
+	return primes
+}
+
+// addPrimeFactors adds the prime factors of n to found, n is destroyed.
+func addPrimeFactors(n *big.Int, found map[string]*big.Int) {
+	// small factors by trial division
+	q, r := new(big.Int), new(big.Int)
+	for d := int64(2); d < 1000 && n.Cmp(bigOne) > 0; d++ {
+		bd := big.NewInt(d)
+		for {
+			q.QuoRem(n, bd, r)
+			if r.Sign() != 0 {
+				break
+			}
+			found[bd.String()] = bd
+			n.Set(q)
+		}
+	}
+
+	var split func(n *big.Int)
+	split = func(n *big.Int) {
+		if n.Cmp(bigOne) <= 0 {
+			return
+		}
+		if n.ProbablyPrime(32) {
+			found[n.String()] = new(big.Int).Set(n)
+			return
+		}
+		d := pollardRho(n)
+		split(d)
+		split(new(big.Int).Quo(n, d))
+	}
+	split(n)
+}
+
+// pollardRho returns a nontrivial factor of the odd composite n.
+func pollardRho(n *big.Int) *big.Int {
+	const batch = 64
+	d, diff, prod := new(big.Int), new(big.Int), new(big.Int)
+
+	for c := int64(1); ; c++ {
+		bc := big.NewInt(c)
+		step := func(v *big.Int) {
+			v.Mul(v, v)
+			v.Add(v, bc)
+			v.Mod(v, n)
+		}
+
+		x, y := big.NewInt(2), big.NewInt(2)
+		for {
+			// gcds are taken on the product of a batch of differences
+			x0, y0 := new(big.Int).Set(x), new(big.Int).Set(y)
+			prod.SetInt64(1)
+			for i := 0; i < batch; i++ {
+				step(x)
+				step(y)
+				step(y)
+				diff.Sub(x, y)
+				prod.Mul(prod, diff.Abs(diff))
+				prod.Mod(prod, n)
+			}
+			d.GCD(nil, nil, prod, n)
+			if d.Cmp(bigOne) == 0 {
+				continue
+			}
+			if d.Cmp(n) == 0 {
+				// replay the batch one step at a time
+				x, y = x0, y0
+				for d.SetInt64(1); d.Cmp(bigOne) == 0; {
+					step(x)
+					step(y)
+					step(y)
+					diff.Sub(x, y)
+					d.GCD(nil, nil, diff.Abs(diff), n)
+				}
+			}
+			if d.Cmp(n) == 0 {
+				// this sequence failed, try another constant
+				break
+			}
+			return new(big.Int).Set(d)
+		}
+	}
+}
+
+// mersenneFactors returns the distinct prime factors of 2^d-1 in increasing order.
+// The factors of 2^k-1 for every divisor k of d are found first, leaving a smaller
+// cofactor to split, and are kept in cache.
+func mersenneFactors(d int, cache map[int][]*big.Int) []*big.Int {
+	if primes, has := cache[d]; has {
+		return primes
+	}
+
+	n := new(big.Int).Lsh(bigOne, uint(d))
+	n.Sub(n, bigOne)
+
+	found := make(map[string]*big.Int)
+	q, r := new(big.Int), new(big.Int)
+	for k := 2; k < d; k++ {
+		if d%k != 0 {
+			continue
+		}
+		for _, p := range mersenneFactors(k, cache) {
+			found[p.String()] = p
+			for {
+				q.QuoRem(n, p, r)
+				if r.Sign() != 0 {
+					break
+				}
+				n.Set(q)
+			}
+		}
+	}
+	addPrimeFactors(n, found)
+
+	primes := sortedFactors(found)
+	cache[d] = primes
+	return primes
+}
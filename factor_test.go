@@ -0,0 +1,48 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestPrimeFactors(t *testing.T) {
+	tests := []struct {
+		n        string
+		expected string
+	}{
+		{"1", "[]"},
+		{"12", "[2 3]"},
+		{"1000003", "[1000003]"},
+		{"999985999949", "[999983 1000003]"},
+		{"18446744073709551615", "[3 5 17 257 641 65537 6700417]"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			n, _ := new(big.Int).SetString(test.n, 10)
+			if actual := fmt.Sprint(primeFactors(n)); actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMersenneFactors(t *testing.T) {
+	tests := []struct {
+		d        int
+		expected string
+	}{
+		{1, "[]"},
+		{6, "[3 7]"},
+		{11, "[23 89]"},
+		{67, "[193707721 761838257287]"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := fmt.Sprint(mersenneFactors(test.d, map[int][]*big.Int{})); actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package sparsemat
+
+// rowReduce returns the reduced row echelon form of m as a new matrix. Pivot
+// columns are chosen in the order given by cols, when cols is nil the natural
+// column order is used. Rows that reduce to zero are dropped, so the returned
+// matrix has rank rows and pivots[i] is the pivot column of row i.
+func rowReduce(m SparseMat, cols []int) (reduced *CSRMatrix, pivots []int) {
+	rows, mCols := m.Dims()
+	reduced = csrMat(rows, mCols)
+	for i := 0; i < rows; i++ {
+		reduced.data[i] = m.Row(i).NonzeroArray()
+	}
+
+	if cols == nil {
+		cols = make([]int, mCols)
+		for j := range cols {
+			cols[j] = j
+		}
+	}
+
+	rank := 0
+	pivots = make([]int, 0)
+	for _, c := range cols {
+		if rank == rows {
+			break
+		}
+
+		p := -1
+		for i := rank; i < rows; i++ {
+			if reduced.at(i, c) == 1 {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+
+		reduced.data[rank], reduced.data[p] = reduced.data[p], reduced.data[rank]
+		for i := 0; i < rows; i++ {
+			if i != rank && reduced.at(i, c) == 1 {
+				reduced.data[i] = addRows(reduced.data[i], reduced.data[rank])
+			}
+		}
+		pivots = append(pivots, c)
+		rank++
+	}
+
+	reduced.rows = rank
+	reduced.data = reduced.data[:rank]
+	return
+}
+
+// NullSpace returns a matrix whose rows are a basis of all vectors x such that
+// m*x = 0. When m is a parity-check matrix the result is a generator matrix of
+// the code, and when m is a generator matrix the result is a parity-check matrix.
+func NullSpace(m SparseMat) SparseMat {
+	return nullSpace(m)
+}
+
+func nullSpace(m SparseMat) *CSRMatrix {
+	_, cols := m.Dims()
+	reduced, pivots := rowReduce(m, nil)
+
+	isPivot := make([]bool, cols)
+	for _, p := range pivots {
+		isPivot[p] = true
+	}
+
+	basis := make([][]int, 0, cols-len(pivots))
+	for f := 0; f < cols; f++ {
+		if isPivot[f] {
+			continue
+		}
+
+		// the free column f is set and each pivot variable is
+		// chosen to cancel it out of its row
+		tmp := map[int]int{f: 1}
+		for i, p := range pivots {
+			if reduced.at(i, f) == 1 {
+				tmp[p] = 1
+			}
+		}
+		basis = append(basis, (&DOKVector{length: cols, values: tmp}).NonzeroArray())
+	}
+
+	ns := csrMat(len(basis), cols)
+	ns.data = basis
+	return ns
+}
+
+// independentRows returns, in order, the indices of the rows of m that are not a
+// linear combination of the rows before them.
+func independentRows(m SparseMat) []int {
+	rows, _ := m.Dims()
+
+	// basis rows are kept in echelon form keyed by their leading column
+	basis := make(map[int][]int)
+	independent := make([]int, 0, rows)
+	for i := 0; i < rows; i++ {
+		v := m.Row(i).NonzeroArray()
+		for len(v) > 0 {
+			b, has := basis[v[0]]
+			if !has {
+				break
+			}
+			v = addRows(v, b)
+		}
+		if len(v) > 0 {
+			basis[v[0]] = v
+			independent = append(independent, i)
+		}
+	}
+	return independent
+}
@@ -0,0 +1,73 @@
+package sparsemat
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNullSpace(t *testing.T) {
+	tests := []struct {
+		m            SparseMat
+		expectedRows int
+	}{
+		{CSRMat(3, 7, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 4},
+		{DOKMat(2, 4, 1, 1, 0, 0, 1, 1, 0, 0), 3},
+		{CSRIdentity(4), 0},
+		{CSRMat(2, 3), 3},
+		{randomMatrix(10, 20), -1},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			ns := NullSpace(test.m)
+			rows, cols := test.m.Dims()
+			nsRows, nsCols := ns.Dims()
+			if nsCols != cols {
+				t.Fatalf("expected %v columns but found %v", cols, nsCols)
+			}
+			if test.expectedRows >= 0 && nsRows != test.expectedRows {
+				t.Fatalf("expected %v rows but found %v", test.expectedRows, nsRows)
+			}
+
+			for i := 0; i < nsRows; i++ {
+				syndrome := CSRVec(rows)
+				syndrome.MatMul(test.m, ns.Row(i))
+				if !syndrome.IsZero() {
+					t.Fatalf("expected %v to be in the null space of \n%v", ns.Row(i), test.m)
+				}
+			}
+
+			if _, pivots := rowReduce(ns, nil); len(pivots) != nsRows {
+				t.Fatalf("expected the null space basis to be independent found rank %v of %v", len(pivots), nsRows)
+			}
+		})
+	}
+}
+
+func TestRowReduce(t *testing.T) {
+	tests := []struct {
+		m              SparseMat
+		cols           []int
+		expected       SparseMat
+		expectedPivots []int
+	}{
+		{CSRMat(2, 3, 1, 1, 0, 1, 1, 1), nil, CSRMat(2, 3, 1, 1, 0, 0, 0, 1), []int{0, 2}},
+		{CSRMat(2, 3, 1, 1, 0, 1, 1, 1), []int{2, 1, 0}, CSRMat(2, 3, 0, 0, 1, 1, 1, 0), []int{2, 1}},
+		{CSRMat(3, 3, 1, 1, 0, 1, 1, 0, 0, 0, 0), nil, CSRMat(1, 3, 1, 1, 0), []int{0}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, pivots := rowReduce(test.m, test.cols)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+			if len(pivots) != len(test.expectedPivots) {
+				t.Fatalf("expected pivots %v but found %v", test.expectedPivots, pivots)
+			}
+			for j := range pivots {
+				if pivots[j] != test.expectedPivots[j] {
+					t.Fatalf("expected pivots %v but found %v", test.expectedPivots, pivots)
+				}
+			}
+		})
+	}
+}
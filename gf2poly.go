@@ -0,0 +1,203 @@
+package sparsemat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// gf2Poly is a polynomial over GF(2) packed into words, bit i%64 of word i/64 is
+// the coefficient of x^i. Polynomials are kept trimmed so the last word is not 0,
+// the zero polynomial has no words.
+type gf2Poly []uint64
+
+// polyFromIndices returns the polynomial with the exponents given by indices.
+func polyFromIndices(indices []int) gf2Poly {
+	if len(indices) == 0 {
+		return gf2Poly{}
+	}
+	p := make(gf2Poly, indices[len(indices)-1]/64+1)
+	for _, i := range indices {
+		p[i/64] ^= 1 << uint(i%64)
+	}
+	return p.trim()
+}
+
+// polyMonomial returns x^n.
+func polyMonomial(n int) gf2Poly {
+	return polyFromIndices([]int{n})
+}
+
+// indices returns the exponents with a nonzero coefficient in increasing order.
+func (p gf2Poly) indices() []int {
+	indices := make([]int, 0)
+	for k, w := range p {
+		for ; w != 0; w &= w - 1 {
+			indices = append(indices, k*64+bits.TrailingZeros64(w))
+		}
+	}
+	return indices
+}
+
+func (p gf2Poly) trim() gf2Poly {
+	n := len(p)
+	for n > 0 && p[n-1] == 0 {
+		n--
+	}
+	return p[:n]
+}
+
+// deg returns the degree of p, -1 for the zero polynomial.
+func (p gf2Poly) deg() int {
+	if len(p) == 0 {
+		return -1
+	}
+	return (len(p)-1)*64 + bits.Len64(p[len(p)-1]) - 1
+}
+
+func (p gf2Poly) isZero() bool {
+	return len(p) == 0
+}
+
+func (p gf2Poly) isOne() bool {
+	return len(p) == 1 && p[0] == 1
+}
+
+func (p gf2Poly) coeff(i int) uint64 {
+	if i < 0 || i/64 >= len(p) {
+		return 0
+	}
+	return p[i/64] >> uint(i%64) & 1
+}
+
+func (p gf2Poly) equals(q gf2Poly) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for k := range p {
+		if p[k] != q[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// xorShifted adds src*x^shift into dst, which must be long enough to hold it.
+func xorShifted(dst []uint64, src gf2Poly, shift int) {
+	ws, bs := shift/64, uint(shift%64)
+	for k, w := range src {
+		dst[k+ws] ^= w << bs
+		if bs > 0 && k+ws+1 < len(dst) {
+			dst[k+ws+1] ^= w >> (64 - bs)
+		}
+	}
+}
+
+func (p gf2Poly) add(q gf2Poly) gf2Poly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := make(gf2Poly, len(p))
+	copy(r, p)
+	for k, w := range q {
+		r[k] ^= w
+	}
+	return r.trim()
+}
+
+func (p gf2Poly) mul(q gf2Poly) gf2Poly {
+	if p.isZero() || q.isZero() {
+		return gf2Poly{}
+	}
+	r := make(gf2Poly, (p.deg()+q.deg())/64+1)
+	for _, i := range p.indices() {
+		xorShifted(r, q, i)
+	}
+	return r.trim()
+}
+
+// divMod returns the quotient and remainder of p divided by the nonzero q.
+func (p gf2Poly) divMod(q gf2Poly) (quo, rem gf2Poly) {
+	if q.isZero() {
+		panic("polynomial division by zero")
+	}
+
+	rem = make(gf2Poly, len(p))
+	copy(rem, p)
+	rem = rem.trim()
+	dq := q.deg()
+	if rem.deg() < dq {
+		return gf2Poly{}, rem
+	}
+
+	quo = make(gf2Poly, (rem.deg()-dq)/64+1)
+	for d := rem.deg(); d >= dq; d = rem.deg() {
+		shift := d - dq
+		quo[shift/64] |= 1 << uint(shift%64)
+		xorShifted(rem, q, shift)
+		rem = rem.trim()
+	}
+	return quo.trim(), rem
+}
+
+func (p gf2Poly) mod(q gf2Poly) gf2Poly {
+	_, r := p.divMod(q)
+	return r
+}
+
+func (p gf2Poly) gcd(q gf2Poly) gf2Poly {
+	for !q.isZero() {
+		p, q = q, p.mod(q)
+	}
+	return p
+}
+
+func (p gf2Poly) lcm(q gf2Poly) gf2Poly {
+	quo, _ := p.mul(q).divMod(p.gcd(q))
+	return quo
+}
+
+func (p gf2Poly) mulMod(q, m gf2Poly) gf2Poly {
+	return p.mul(q).mod(m)
+}
+
+// powMod returns p^e mod m.
+func (p gf2Poly) powMod(e *big.Int, m gf2Poly) gf2Poly {
+	r := polyMonomial(0).mod(m)
+	base := p.mod(m)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		r = r.mulMod(r, m)
+		if e.Bit(i) == 1 {
+			r = r.mulMod(base, m)
+		}
+	}
+	return r
+}
+
+// irreducibleFactorDegrees returns in increasing order the distinct degrees of
+// the irreducible factors of f, using distinct degree factorization.
+func (p gf2Poly) irreducibleFactorDegrees() []int {
+	x := polyMonomial(1)
+	degrees := make([]int, 0)
+
+	f := p
+	h := x.mod(f)
+	for d := 1; 2*d <= f.deg(); d++ {
+		// x^(2^d)-x is the product of all irreducibles of degree dividing d
+		h = h.mulMod(h, f)
+		g := h.add(x).gcd(f)
+		if g.deg() <= 0 {
+			continue
+		}
+		degrees = append(degrees, d)
+		for g = f.gcd(g); g.deg() > 0; g = f.gcd(g) {
+			f, _ = f.divMod(g)
+		}
+		h = h.mod(f)
+	}
+
+	// the factors left are all of one degree above d and there is room for only one
+	if f.deg() > 0 {
+		degrees = append(degrees, f.deg())
+	}
+	return degrees
+}
@@ -0,0 +1,80 @@
+package sparsemat
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestGF2Poly_divMod(t *testing.T) {
+	tests := []struct {
+		a, b     []int
+		quo, rem []int
+	}{
+		{[]int{0, 2}, []int{0, 1}, []int{0, 1}, []int{}},
+		{[]int{0, 1, 3}, []int{0, 1}, []int{1, 2}, []int{0}},
+		{[]int{1}, []int{0, 2}, []int{}, []int{1}},
+		{[]int{0, 70, 130}, []int{0, 65}, []int{0, 5, 65}, []int{5}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			a, b := polyFromIndices(test.a), polyFromIndices(test.b)
+			quo, rem := a.divMod(b)
+			if !reflect.DeepEqual(quo.indices(), test.quo) || !reflect.DeepEqual(rem.indices(), test.rem) {
+				t.Fatalf("expected %v, %v but found %v, %v", test.quo, test.rem, quo.indices(), rem.indices())
+			}
+			if !quo.mul(b).add(rem).equals(a) {
+				t.Fatalf("expected quo*b+rem to be %v", test.a)
+			}
+		})
+	}
+}
+
+func TestGF2Poly_gcd(t *testing.T) {
+	// (x+1)(x^2+x+1) and (x+1)^2
+	a := polyFromIndices([]int{0, 3})
+	b := polyFromIndices([]int{0, 2})
+	if actual := a.gcd(b); !reflect.DeepEqual(actual.indices(), []int{0, 1}) {
+		t.Fatalf("expected x+1 but found %v", actual.indices())
+	}
+	if actual := a.lcm(b); !reflect.DeepEqual(actual.indices(), []int{0, 1, 3, 4}) {
+		t.Fatalf("expected x^4+x^3+x+1 but found %v", actual.indices())
+	}
+}
+
+func TestGF2Poly_powMod(t *testing.T) {
+	m := polyFromIndices([]int{0, 1, 4})
+	x := polyMonomial(1)
+	if actual := x.powMod(big.NewInt(15), m); !actual.isOne() {
+		t.Fatalf("expected x^15 = 1 mod x^4+x+1 found %v", actual.indices())
+	}
+	if actual := x.powMod(big.NewInt(5), m); actual.isOne() {
+		t.Fatalf("expected x^5 != 1 mod x^4+x+1")
+	}
+}
+
+func TestGF2Poly_irreducibleFactorDegrees(t *testing.T) {
+	tests := []struct {
+		p        []int
+		expected []int
+	}{
+		{[]int{0, 1, 4}, []int{4}},
+		{[]int{0, 2}, []int{1}},
+		{[]int{1, 2}, []int{1}},
+		// (x^2+x+1)(x^3+x+1)
+		{[]int{0, 4, 5}, []int{2, 3}},
+		// (x^3+x+1)^2
+		{[]int{0, 2, 6}, []int{3}},
+		// x(x+1)(x^2+x+1)^2
+		{[]int{1, 2, 3, 4, 5, 6}, []int{1, 2}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := polyFromIndices(test.p).irreducibleFactorDegrees()
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 module github.com/nathanhack/sparsemat
 
-go 1.14
+go 1.18
 
-require github.com/olekukonko/tablewriter v0.0.4
+require (
+	github.com/olekukonko/tablewriter v0.0.4
+	gonum.org/v1/gonum v0.12.0
+)
+
+require github.com/mattn/go-runewidth v0.0.7 // indirect
@@ -0,0 +1,41 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// CSRMatFromGonum creates a new matrix from a gonum matrix. Every element is
+// rounded to the nearest integer and reduced mod 2, so any even value gives a 0
+// and any odd value gives a 1. NaN and infinite elements panic.
+func CSRMatFromGonum(a mat.Matrix) SparseMat {
+	rows, cols := a.Dims()
+	m := csrMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			v := a.At(i, j)
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				panic(fmt.Sprintf("element (%v,%v) is %v", i, j, v))
+			}
+			if math.Mod(math.Round(v), 2) != 0 {
+				m.data[i] = append(m.data[i], j)
+			}
+		}
+	}
+	return m
+}
+
+// MatToGonum returns the matrix as a gonum dense matrix of 0's and 1's.
+// As gonum does not allow empty matrices, m must have at least one row and column.
+func MatToGonum(m SparseMat) *mat.Dense {
+	rows, cols := m.Dims()
+	d := mat.NewDense(rows, cols, nil)
+	for i := 0; i < rows; i++ {
+		for _, j := range m.Row(i).NonzeroArray() {
+			d.Set(i, j, 1)
+		}
+	}
+	return d
+}
@@ -0,0 +1,43 @@
+package sparsemat
+
+import (
+	"strconv"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCSRMatFromGonum(t *testing.T) {
+	tests := []struct {
+		a        mat.Matrix
+		expected SparseMat
+	}{
+		{mat.NewDense(2, 2, []float64{1, 0, 0, 1}), CSRIdentity(2)},
+		{mat.NewDense(1, 4, []float64{2, 3, -1, 0.9}), CSRMat(1, 4, 0, 1, 1, 1)},
+		{mat.NewDense(2, 3, []float64{1, 0, 1, 0, 1, 0}).T(), CSRMat(3, 2, 1, 0, 0, 1, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRMatFromGonum(test.a)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatToGonum(t *testing.T) {
+	m := randomMatrix(7, 9)
+	d := MatToGonum(m)
+	for i := 0; i < 7; i++ {
+		for j := 0; j < 9; j++ {
+			if d.At(i, j) != float64(m.At(i, j)) {
+				t.Fatalf("expected %v at (%v,%v) but found %v", m.At(i, j), i, j, d.At(i, j))
+			}
+		}
+	}
+
+	if actual := CSRMatFromGonum(d); !actual.Equals(m) {
+		t.Fatalf("expected \n%v\nbut found\n%v", m, actual)
+	}
+}
@@ -0,0 +1,196 @@
+package sparsemat
+
+import (
+	"math/rand"
+)
+
+// MinDistanceUpperBound searches for low weight codewords of the code spanned by
+// the rows of the generator matrix g using Stern's information set decoding. Each
+// of the iterations picks a random information set (drawn from a source seeded
+// with seed), brings g into systematic form on it and checks every row as well as
+// every pair of rows that cancel on a small window of redundancy columns.
+// The weight of the lightest codeword found is returned along with the codeword
+// itself, this weight is an upper bound on the minimum distance of the code.
+// If the code has no nonzero codewords 0 and nil are returned.
+func MinDistanceUpperBound(g SparseMat, iterations int, seed int64) (distance int, codeword SparseVector) {
+	if iterations <= 0 {
+		panic("iterations must be > 0")
+	}
+
+	_, n := g.Dims()
+	r := rand.New(rand.NewSource(seed))
+
+	var best []int
+	for it := 0; it < iterations; it++ {
+		perm := r.Perm(n)
+		reduced, pivots := rowReduce(g, perm)
+		if len(pivots) == 0 {
+			return 0, nil
+		}
+
+		c := sternSearch(reduced, pivots, perm)
+		if best == nil || len(c) < len(best) {
+			best = c
+		}
+	}
+
+	return len(best), &CSRVector{length: n, indices: best}
+}
+
+// MinDistanceUpperBoundFromParityCheck is MinDistanceUpperBound for the code whose
+// parity-check matrix is h, that is the code made of the vectors c with h*c = 0.
+func MinDistanceUpperBoundFromParityCheck(h SparseMat, iterations int, seed int64) (distance int, codeword SparseVector) {
+	return MinDistanceUpperBound(NullSpace(h), iterations, seed)
+}
+
+// sternSearch looks for a light codeword in the systematic matrix reduced whose
+// pivot columns were chosen in the order given by perm.
+func sternSearch(reduced *CSRMatrix, pivots, perm []int) []int {
+	k := len(pivots)
+
+	// every row has weight one on the information set
+	best := reduced.data[0]
+	for _, row := range reduced.data {
+		if len(row) < len(best) {
+			best = row
+		}
+	}
+
+	isPivot := make(map[int]bool, k)
+	for _, p := range pivots {
+		isPivot[p] = true
+	}
+
+	// the window is made of the first redundancy columns in the random order
+	l := 1
+	for 1<<uint(l+1) <= k && l < 20 {
+		l++
+	}
+	window := make([]int, 0, l)
+	for _, c := range perm {
+		if len(window) == l {
+			break
+		}
+		if !isPivot[c] {
+			window = append(window, c)
+		}
+	}
+	if len(window) == 0 {
+		return best
+	}
+
+	key := func(i int) int {
+		v := 0
+		for b, c := range window {
+			v |= reduced.at(i, c) << uint(b)
+		}
+		return v
+	}
+
+	// split the information set in two halves and look for collisions
+	// on the window, the sum then has weight two on the information set
+	half := k / 2
+	buckets := make(map[int][]int)
+	for i := 0; i < half; i++ {
+		kv := key(i)
+		buckets[kv] = append(buckets[kv], i)
+	}
+	for j := half; j < k; j++ {
+		for _, i := range buckets[key(j)] {
+			sum := addRows(reduced.data[i], reduced.data[j])
+			if len(sum) < len(best) {
+				best = sum
+			}
+		}
+	}
+
+	return best
+}
+
+// MinDistance computes the exact minimum distance of the code spanned by the rows
+// of the generator matrix g using the Brouwer-Zimmermann algorithm and returns it
+// together with a codeword of that weight. The search enumerates combinations of
+// rows of systematic generator matrices on disjoint information sets, so its
+// running time grows quickly with the dimension of the code and it is only
+// suited to small codes. If the code has no nonzero codewords 0 and nil are returned.
+func MinDistance(g SparseMat) (distance int, codeword SparseVector) {
+	_, n := g.Dims()
+
+	// find as many systematic forms on disjoint information sets as possible
+	remaining := make([]int, n)
+	for j := range remaining {
+		remaining[j] = j
+	}
+	systematic := make([]*CSRMatrix, 0)
+	k := -1
+	for {
+		reduced, pivots := rowReduce(g, remaining)
+		if k < 0 {
+			k = len(pivots)
+			if k == 0 {
+				return 0, nil
+			}
+		}
+		if len(pivots) < k {
+			break
+		}
+		systematic = append(systematic, reduced)
+
+		used := make(map[int]bool, k)
+		for _, p := range pivots {
+			used[p] = true
+		}
+		next := make([]int, 0, len(remaining)-k)
+		for _, c := range remaining {
+			if !used[c] {
+				next = append(next, c)
+			}
+		}
+		remaining = next
+	}
+
+	best := systematic[0].data[0]
+	for w := 1; w <= k; w++ {
+		for _, s := range systematic {
+			c := lightestCombination(s.data, w, best)
+			if len(c) < len(best) {
+				best = c
+			}
+		}
+
+		// a codeword not yet seen has more than w ones on each information set
+		if len(systematic)*(w+1) >= len(best) {
+			break
+		}
+	}
+
+	indices := make([]int, len(best))
+	copy(indices, best)
+	return len(indices), &CSRVector{length: n, indices: indices}
+}
+
+// MinDistanceFromParityCheck is MinDistance for the code whose parity-check matrix
+// is h, that is the code made of the vectors c with h*c = 0.
+func MinDistanceFromParityCheck(h SparseMat) (distance int, codeword SparseVector) {
+	return MinDistance(NullSpace(h))
+}
+
+// lightestCombination returns the lightest sum of exactly w of the rows that is
+// lighter than best, or best when no such sum exists.
+func lightestCombination(rows [][]int, w int, best []int) []int {
+	var search func(start, depth int, acc []int)
+	search = func(start, depth int, acc []int) {
+		if depth == w {
+			if len(acc) < len(best) {
+				best = acc
+			}
+			return
+		}
+		for i := start; i <= len(rows)-(w-depth); i++ {
+			search(i+1, depth+1, addRows(acc, rows[i]))
+		}
+	}
+	search(0, 0, []int{})
+
+	return best
+}
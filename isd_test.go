@@ -0,0 +1,156 @@
+package sparsemat
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// bruteForceMinDistance checks every nonzero combination of the rows of g.
+func bruteForceMinDistance(g SparseMat) int {
+	rows, cols := g.Dims()
+	best := 0
+	for mask := 1; mask < 1<<uint(rows); mask++ {
+		c := CSRVec(cols)
+		for i := 0; i < rows; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				c.Add(c, g.Row(i))
+			}
+		}
+		w := c.HammingWeight()
+		if w > 0 && (best == 0 || w < best) {
+			best = w
+		}
+	}
+	return best
+}
+
+func checkCodeword(t *testing.T, g SparseMat, c SparseVector) {
+	rows, _ := g.Dims()
+	h := NullSpace(g)
+	hRows, _ := h.Dims()
+	syndrome := CSRVec(hRows)
+	syndrome.MatMul(h, c)
+	if rows > 0 && !syndrome.IsZero() {
+		t.Fatalf("expected %v to be a codeword", c)
+	}
+}
+
+func TestMinDistance(t *testing.T) {
+	tests := []struct {
+		g        SparseMat
+		expected int
+	}{
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 3},
+		{CSRMat(4, 8, 1, 0, 0, 0, 0, 1, 1, 1, 0, 1, 0, 0, 1, 0, 1, 1, 0, 0, 1, 0, 1, 1, 0, 1, 0, 0, 0, 1, 1, 1, 1, 0), 4},
+		{DOKMat(1, 5, 1, 1, 1, 1, 1), 5},
+		{CSRMat(2, 4, 1, 1, 0, 0, 1, 1, 0, 0), 2},
+		{CSRMat(2, 3), 0},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			d, c := MinDistance(test.g)
+			if d != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, d)
+			}
+			if d == 0 {
+				if c != nil {
+					t.Fatalf("expected no codeword but found %v", c)
+				}
+				return
+			}
+			if c.HammingWeight() != d {
+				t.Fatalf("expected codeword of weight %v but found %v", d, c)
+			}
+			checkCodeword(t, test.g, c)
+		})
+	}
+}
+
+func TestMinDistance_random(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rows, cols := 1+rand.Intn(8), 10+rand.Intn(10)
+			g := randomMatrix(rows, cols)
+			expected := bruteForceMinDistance(g)
+
+			d, c := MinDistance(g)
+			if d != expected {
+				t.Fatalf("expected %v but found %v for \n%v", expected, d, g)
+			}
+			if d > 0 {
+				checkCodeword(t, g, c)
+			}
+
+			ub, c := MinDistanceUpperBound(g, 50, int64(i))
+			if ub < expected {
+				t.Fatalf("upper bound %v is below the minimum distance %v", ub, expected)
+			}
+			if ub > 0 {
+				if c.HammingWeight() != ub {
+					t.Fatalf("expected codeword of weight %v but found %v", ub, c)
+				}
+				checkCodeword(t, g, c)
+			}
+		})
+	}
+}
+
+func TestMinDistanceUpperBound(t *testing.T) {
+	tests := []struct {
+		g        SparseMat
+		expected int
+	}{
+		{CSRMat(4, 7, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 0, 1, 0, 1, 0, 0, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1), 3},
+		{NullSpace(CSRMat(3, 7, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 1)), 3},
+		{CSRMat(2, 3), 0},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			d1, c1 := MinDistanceUpperBound(test.g, 20, 1)
+			d2, c2 := MinDistanceUpperBound(test.g, 20, 1)
+			if d1 != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, d1)
+			}
+			if d1 != d2 || (c1 != nil && !c1.Equals(c2)) {
+				t.Fatalf("expected the same seed to give the same result")
+			}
+		})
+	}
+}
+
+func TestMinDistanceFromParityCheck(t *testing.T) {
+	_, hamming := HammingCode(3)
+	_, rm := ReedMullerCode(1, 4)
+	_, parity := SingleParityCheckCode(5)
+	tests := []struct {
+		h        SparseMat
+		expected int
+	}{
+		{hamming, 3},
+		{rm, 8},
+		{parity, 2},
+		{CSRIdentity(3), 0},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			d, c := MinDistanceFromParityCheck(test.h)
+			if d != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, d)
+			}
+			bound, _ := MinDistanceUpperBoundFromParityCheck(test.h, 20, 1)
+			if bound < d || (d > 0 && bound == 0) {
+				t.Fatalf("expected an upper bound of %v but found %v", d, bound)
+			}
+			if d == 0 {
+				return
+			}
+			hRows, _ := test.h.Dims()
+			syndrome := CSRVec(hRows)
+			syndrome.MatMul(test.h, c)
+			if c.HammingWeight() != d || !syndrome.IsZero() {
+				t.Fatalf("expected a codeword of weight %v but found %v", d, c)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package sparsemat
+
+import (
+	"fmt"
+)
+
+// Kron returns the Kronecker product of a and b, the matrix made of the blocks
+// a[i][j]*b. The result is a DOK matrix when a is one and a CSR matrix otherwise.
+func Kron(a, b SparseMat) SparseMat {
+	aRows, aCols := a.Dims()
+	bRows, bCols := b.Dims()
+
+	aData := make([][]int, aRows)
+	for i := range aData {
+		aData[i] = a.Row(i).NonzeroArray()
+	}
+	bData := make([][]int, bRows)
+	for k := range bData {
+		bData[k] = b.Row(k).NonzeroArray()
+	}
+
+	// with both rows sorted the indices j*bCols+l come out sorted
+	m := csrMat(aRows*bRows, aCols*bCols)
+	for i, aRow := range aData {
+		for k, bRow := range bData {
+			row := make([]int, 0, len(aRow)*len(bRow))
+			for _, j := range aRow {
+				for _, l := range bRow {
+					row = append(row, j*bCols+l)
+				}
+			}
+			m.data[i*bRows+k] = row
+		}
+	}
+
+	if _, ok := a.(*DOKMatrix); ok {
+		return DOKMatCopy(m)
+	}
+	return m
+}
+
+// KronPower returns the n-fold Kronecker product of a with itself, for n = 0
+// this is the 1x1 matrix [1]. The result has the same backend as Kron.
+// For example KronPower(CSRMat(2, 2, 1, 0, 1, 1), n) is the kernel of the polar
+// code of length 2^n.
+func KronPower(a SparseMat, n int) SparseMat {
+	if n < 0 {
+		panic(fmt.Sprintf("power must be >= 0 found %v", n))
+	}
+
+	var m SparseMat = CSRIdentity(1)
+	if _, ok := a.(*DOKMatrix); ok {
+		m = DOKIdentity(1)
+	}
+	for ; n > 0; n-- {
+		m = Kron(m, a)
+	}
+	return m
+}
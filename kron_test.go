@@ -0,0 +1,97 @@
+package sparsemat
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestKron(t *testing.T) {
+	tests := []struct {
+		a, b     SparseMat
+		expected SparseMat
+	}{
+		{CSRIdentity(2), CSRMat(1, 2, 1, 1), CSRMat(2, 4, 1, 1, 0, 0, 0, 0, 1, 1)},
+		{CSRMat(1, 2, 1, 1), CSRIdentity(2), CSRMat(2, 4, 1, 0, 1, 0, 0, 1, 0, 1)},
+		{DOKMat(2, 2, 1, 0, 1, 1), CSRMat(2, 1, 0, 1), CSRMat(4, 2, 0, 0, 1, 0, 0, 0, 1, 1)},
+		{CSRMat(0, 2), CSRIdentity(2), CSRMat(0, 4)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Kron(test.a, test.b)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", test.expected, actual)
+			}
+			if _, isDOK := test.a.(*DOKMatrix); isDOK {
+				if _, ok := actual.(*DOKMatrix); !ok {
+					t.Fatalf("expected a DOK matrix but found %T", actual)
+				}
+			}
+		})
+	}
+}
+
+func TestKron_mixedProduct(t *testing.T) {
+	a, b := randomMatrix(3, 4), randomMatrix(2, 5)
+	c, d := randomMatrix(4, 2), randomMatrix(5, 3)
+
+	ac := CSRMat(3, 2)
+	ac.Mul(a, c)
+	bd := CSRMat(2, 3)
+	bd.Mul(b, d)
+
+	actual := CSRMat(6, 6)
+	actual.Mul(Kron(a, b), Kron(c, d))
+	if expected := Kron(ac, bd); !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\nbut found\n%v", expected, actual)
+	}
+}
+
+func TestKronPower(t *testing.T) {
+	kernel := CSRMat(2, 2, 1, 0, 1, 1)
+	tests := []struct {
+		n        int
+		expected SparseMat
+	}{
+		{0, CSRIdentity(1)},
+		{1, kernel},
+		{2, CSRMat(4, 4, 1, 0, 0, 0, 1, 1, 0, 0, 1, 0, 1, 0, 1, 1, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := KronPower(kernel, test.n)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestKronPower_reedMuller(t *testing.T) {
+	// the rows of the polar kernel power of weight at least 2^(m-r) span RM(r,m)
+	m := 4
+	g := KronPower(DOKMat(2, 2, 1, 0, 1, 1), m)
+	for r := 0; r <= m; r++ {
+		t.Run(strconv.Itoa(r), func(t *testing.T) {
+			rows := make([]int, 0)
+			for i := 0; i < 1<<uint(m); i++ {
+				if g.Row(i).HammingWeight() >= 1<<uint(m-r) {
+					rows = append(rows, i)
+				}
+			}
+
+			rm, _ := ReedMullerCode(r, m)
+			sub := g.SelectRows(rows)
+			if d, _ := MinDistance(sub); d != 1<<uint(m-r) {
+				t.Fatalf("expected distance %v but found %v", 1<<uint(m-r), d)
+			}
+			both := CSRMatCopy(rm)
+			for _, i := range rows {
+				both.AppendRow(g.Row(i))
+			}
+			rmRows, _ := rm.Dims()
+			if len(independentRows(both)) != rmRows || len(rows) != rmRows {
+				t.Fatalf("expected the selected rows to span RM(%v,%v)", r, m)
+			}
+		})
+	}
+}
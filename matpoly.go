@@ -0,0 +1,230 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// mulRows returns the rows of the product of the matrices with rows a and b, row
+// i of the product is the sum of the rows of b selected by row i of a.
+func mulRows(a, b [][]int, cols int) [][]int {
+	odd := make([]bool, cols)
+	stamp := make([]int, cols)
+	product := make([][]int, len(a))
+	for i, aRow := range a {
+		touched := make([]int, 0)
+		for _, k := range aRow {
+			for _, j := range b[k] {
+				if stamp[j] != i+1 {
+					stamp[j] = i + 1
+					touched = append(touched, j)
+				}
+				odd[j] = !odd[j]
+			}
+		}
+
+		row := make([]int, 0, len(touched))
+		for _, j := range touched {
+			if odd[j] {
+				row = append(row, j)
+				odd[j] = false
+			}
+		}
+		sort.Ints(row)
+		product[i] = row
+	}
+	return product
+}
+
+func checkSquare(m SparseMat) int {
+	rows, cols := m.Dims()
+	if rows != cols {
+		panic(fmt.Sprintf("matrix must be square found (%v,%v)", rows, cols))
+	}
+	return rows
+}
+
+func matRows(m SparseMat) [][]int {
+	rows, _ := m.Dims()
+	data := make([][]int, rows)
+	for i := range data {
+		data[i] = m.Row(i).NonzeroArray()
+	}
+	return data
+}
+
+// Pow returns m^k for the square matrix m and k >= 0 as a new CSR matrix, computed
+// by repeated squaring with a sparse row by row product.
+func Pow(m SparseMat, k int) SparseMat {
+	n := checkSquare(m)
+	if k < 0 {
+		panic(fmt.Sprintf("power must be >= 0 found %v", k))
+	}
+
+	result := csrMat(n, n)
+	for i := range result.data {
+		result.data[i] = []int{i}
+	}
+	base := matRows(m)
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result.data = mulRows(result.data, base, n)
+		}
+		if k > 1 {
+			base = mulRows(base, base, n)
+		}
+	}
+	return result
+}
+
+// krylovBasis holds vectors in echelon form keyed by their leading index along
+// with the krylov vectors, by number, that they are the sum of.
+type krylovBasis struct {
+	vectors map[int][]int
+	sums    map[int][]int
+	count   int
+}
+
+func newKrylovBasis() *krylovBasis {
+	return &krylovBasis{vectors: map[int][]int{}, sums: map[int][]int{}}
+}
+
+// reduce returns what is left of v after removing the basis vectors and the
+// krylov vectors these are the sum of.
+func (b *krylovBasis) reduce(v []int) (rest, sum []int) {
+	sum = []int{}
+	for len(v) > 0 {
+		lead := v[0]
+		bv, has := b.vectors[lead]
+		if !has {
+			break
+		}
+		v = addRows(v, bv)
+		sum = addRows(sum, b.sums[lead])
+	}
+	return v, sum
+}
+
+// chain adds v, m*v, m^2*v, ... to the basis until the next vector m^k*v is in
+// its span, and returns the polynomial x^k + sum of c_j*x^j with m^k*v equal to
+// the sum of c_j*m^j*v plus vectors of the basis from before the call.
+// columns holds the columns of m.
+func (b *krylovBasis) chain(columns [][]int, v []int) gf2Poly {
+	start := b.count
+	for k := 0; ; k++ {
+		rest, sum := b.reduce(v)
+		if len(rest) == 0 {
+			exponents := []int{}
+			for _, g := range sum {
+				if g >= start {
+					exponents = append(exponents, g-start)
+				}
+			}
+			return polyFromIndices(append(exponents, k))
+		}
+
+		id := b.count
+		b.count++
+		b.vectors[rest[0]] = rest
+		b.sums[rest[0]] = addRows(sum, []int{id})
+
+		// m*v is the sum of the columns selected by v
+		next := []int{}
+		for _, j := range v {
+			next = addRows(next, columns[j])
+		}
+		v = next
+	}
+}
+
+// matPolys returns the characteristic and minimal polynomials of the square matrix m.
+// Krylov chains of unit vectors are added to a basis until it spans the whole
+// space, in that basis m is block triangular with companion blocks whose product
+// is the characteristic polynomial. The minimal polynomial is the lcm of the
+// minimal polynomials of the vectors the chains start from.
+func matPolys(m SparseMat) (char, min gf2Poly) {
+	n := checkSquare(m)
+	columns := matRows(m.T())
+
+	char = polyMonomial(0)
+	min = polyMonomial(0)
+	basis := newKrylovBasis()
+	for i := 0; i < n && basis.count < n; i++ {
+		e := []int{i}
+		if rest, _ := basis.reduce(e); len(rest) == 0 {
+			continue
+		}
+		char = char.mul(basis.chain(columns, e))
+		min = min.lcm(newKrylovBasis().chain(columns, e))
+	}
+	return
+}
+
+// CharPoly returns the characteristic polynomial of the square matrix m as the
+// vector of its coefficients, index i holding the coefficient of x^i.
+func CharPoly(m SparseMat) SparseVector {
+	char, _ := matPolys(m)
+	return &CSRVector{length: char.deg() + 1, indices: char.indices()}
+}
+
+// MinPoly returns the minimal polynomial of the square matrix m, the monic
+// polynomial p of least degree with p(m) = 0, as the vector of its coefficients,
+// index i holding the coefficient of x^i.
+func MinPoly(m SparseMat) SparseVector {
+	_, min := matPolys(m)
+	return &CSRVector{length: min.deg() + 1, indices: min.indices()}
+}
+
+// Order returns the multiplicative order of the invertible square matrix m, the
+// smallest k > 0 with m^k = I. For the companion matrix of a linear feedback shift
+// register this is its period.
+// The order is that of x modulo the minimal polynomial of m. It divides the lcm of
+// 2^d-1 over the degrees d of the irreducible factors of the minimal polynomial,
+// times a power of 2 covering repeated factors, and is found by removing the prime
+// factors of that multiple one at a time. Factoring 2^d-1 can be slow for large d.
+func Order(m SparseMat) *big.Int {
+	_, min := matPolys(m)
+	if min.coeff(0) == 0 {
+		panic("matrix is not invertible")
+	}
+	if min.deg() <= 0 {
+		return big.NewInt(1)
+	}
+
+	// a multiple of the order and its prime factors
+	order := big.NewInt(1)
+	primes := make(map[string]*big.Int)
+	cache := make(map[int][]*big.Int)
+	for _, d := range min.irreducibleFactorDegrees() {
+		mersenne := new(big.Int).Lsh(bigOne, uint(d))
+		mersenne.Sub(mersenne, bigOne)
+		g := new(big.Int).GCD(nil, nil, order, mersenne)
+		order.Mul(order, mersenne.Quo(mersenne, g))
+		for _, p := range mersenneFactors(d, cache) {
+			primes[p.String()] = p
+		}
+	}
+	twos := 0
+	for 1<<uint(twos) < min.deg() {
+		twos++
+	}
+	if twos > 0 {
+		order.Lsh(order, uint(twos))
+		primes["2"] = big.NewInt(2)
+	}
+
+	x := polyMonomial(1)
+	one := polyMonomial(0)
+	q, r := new(big.Int), new(big.Int)
+	for _, p := range sortedFactors(primes) {
+		for {
+			q.QuoRem(order, p, r)
+			if r.Sign() != 0 || !x.powMod(q, min).equals(one) {
+				break
+			}
+			order.Set(q)
+		}
+	}
+	return order
+}
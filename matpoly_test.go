@@ -0,0 +1,145 @@
+package sparsemat
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+// companion returns the companion matrix of the monic polynomial with the given
+// coefficients, index i holding the coefficient of x^i.
+func companion(coefficients ...int) SparseMat {
+	n := len(coefficients) - 1
+	m := CSRMat(n, n)
+	for i := 0; i+1 < n; i++ {
+		m.Set(i+1, i, 1)
+	}
+	for i := 0; i < n; i++ {
+		m.Set(i, n-1, coefficients[i])
+	}
+	return m
+}
+
+// polyFromExponents returns the coefficients of the polynomial with the given exponents.
+func polyFromExponents(exponents ...int) []int {
+	coefficients := make([]int, exponents[len(exponents)-1]+1)
+	for _, e := range exponents {
+		coefficients[e] = 1
+	}
+	return coefficients
+}
+
+// evaluate returns p(m) for the polynomial p given as a coefficient vector.
+func evaluate(p SparseVector, m SparseMat) SparseMat {
+	n, _ := m.Dims()
+	result := CSRMat(n, n)
+	for _, i := range p.NonzeroArray() {
+		result.Add(result, Pow(m, i))
+	}
+	return result
+}
+
+func TestPow(t *testing.T) {
+	m := randomMatrix(12, 12)
+	expected := CSRIdentity(12)
+	for k := 0; k < 10; k++ {
+		t.Run(strconv.Itoa(k), func(t *testing.T) {
+			if actual := Pow(m, k); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\nbut found\n%v", expected, actual)
+			}
+			next := CSRMat(12, 12)
+			next.Mul(expected, m)
+			expected = next
+		})
+	}
+}
+
+func TestCharPolyMinPoly(t *testing.T) {
+	jordan := CSRMat(3, 3, 1, 1, 0, 0, 1, 1, 0, 0, 1)
+	tests := []struct {
+		m    SparseMat
+		char SparseVector
+		min  SparseVector
+	}{
+		{CSRIdentity(3), CSRVec(4, 1, 1, 1, 1), CSRVec(2, 1, 1)},
+		{CSRMat(2, 2), CSRVec(3, 0, 0, 1), CSRVec(2, 0, 1)},
+		{jordan, CSRVec(4, 1, 1, 1, 1), CSRVec(4, 1, 1, 1, 1)},
+		{companion(1, 1, 0, 0, 1), CSRVec(5, 1, 1, 0, 0, 1), CSRVec(5, 1, 1, 0, 0, 1)},
+		{DirectSum(companion(1, 1, 1), companion(1, 1, 1)), CSRVec(5, 1, 0, 1, 0, 1), CSRVec(3, 1, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := CharPoly(test.m); !actual.Equals(test.char) {
+				t.Fatalf("expected characteristic polynomial %v but found %v", test.char, actual)
+			}
+			if actual := MinPoly(test.m); !actual.Equals(test.min) {
+				t.Fatalf("expected minimal polynomial %v but found %v", test.min, actual)
+			}
+		})
+	}
+}
+
+func TestCharPolyMinPoly_random(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			m := randomMatrix(15, 15)
+			char := CharPoly(m)
+			min := MinPoly(m)
+
+			if char.Len() != 16 || char.At(15) != 1 {
+				t.Fatalf("expected a monic characteristic polynomial of degree 15 found %v", char)
+			}
+			if !evaluate(char, m).Equals(CSRMat(15, 15)) {
+				t.Fatalf("expected the characteristic polynomial to vanish on \n%v", m)
+			}
+			if !evaluate(min, m).Equals(CSRMat(15, 15)) {
+				t.Fatalf("expected the minimal polynomial to vanish on \n%v", m)
+			}
+			_, rem := polyFromIndices(char.NonzeroArray()).divMod(polyFromIndices(min.NonzeroArray()))
+			if !rem.isZero() {
+				t.Fatalf("expected the minimal polynomial %v to divide %v", min, char)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	mersenne := func(d uint) *big.Int {
+		n := new(big.Int).Lsh(big.NewInt(1), d)
+		return n.Sub(n, big.NewInt(1))
+	}
+
+	tests := []struct {
+		m        SparseMat
+		expected *big.Int
+	}{
+		{CSRIdentity(4), big.NewInt(1)},
+		{CSRMat(2, 2, 0, 1, 1, 0), big.NewInt(2)},
+		{CSRMat(3, 3, 1, 1, 0, 0, 1, 1, 0, 0, 1), big.NewInt(4)},
+		{companion(1, 1, 0, 0, 1), big.NewInt(15)},
+		{companion(1, 1, 1, 1, 1), big.NewInt(5)},
+		{DirectSum(companion(1, 1, 1), companion(1, 1, 0, 1)), big.NewInt(21)},
+		{companion(polyFromExponents(0, 1, 3, 4, 64)...), mersenne(64)},
+		{companion(polyFromExponents(0, 1, 127)...), mersenne(127)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Order(test.m)
+			if actual.Cmp(test.expected) != 0 {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if actual.IsInt64() && !Pow(test.m, int(actual.Int64())).Equals(CSRIdentity(checkSquare(test.m))) {
+				t.Fatalf("expected m^%v to be the identity", actual)
+			}
+		})
+	}
+}
+
+func TestOrder_singular(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a singular matrix")
+		}
+	}()
+	Order(CSRMat(2, 2, 1, 1, 1, 1))
+}
@@ -0,0 +1,193 @@
+package sparsemat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MatrixMarketField is the field of the values in a Matrix Market file.
+type MatrixMarketField string
+
+const (
+	// MatrixMarketPattern files only list the positions of the nonzero entries.
+	MatrixMarketPattern MatrixMarketField = "pattern"
+	// MatrixMarketInteger files list an integer value with each position.
+	MatrixMarketInteger MatrixMarketField = "integer"
+)
+
+const matrixMarketBanner = "%%MatrixMarket"
+
+// ReadMatrixMarket reads a matrix in the Matrix Market coordinate format with the
+// pattern or integer field. Integer values are reduced mod 2, unless binaryOnly is
+// set in which case values other than 0 and 1 are rejected. Entries listed more than
+// once are summed mod 2. For the symmetric and skew-symmetric formats only one
+// triangle is stored in the file and it is mirrored into the other triangle.
+func ReadMatrixMarket(reader io.Reader, binaryOnly bool) (SparseMat, error) {
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("matrix market: empty file")
+	}
+	line++
+	header := strings.Fields(strings.ToLower(scanner.Text()))
+	if len(header) != 5 || header[0] != strings.ToLower(matrixMarketBanner) {
+		return nil, fmt.Errorf("matrix market line 1: expected header \"%v matrix coordinate <field> <symmetry>\"", matrixMarketBanner)
+	}
+	if header[1] != "matrix" || header[2] != "coordinate" {
+		return nil, fmt.Errorf("matrix market line 1: unsupported format %q %q, only matrix coordinate is supported", header[1], header[2])
+	}
+	field := MatrixMarketField(header[3])
+	if field != MatrixMarketPattern && field != MatrixMarketInteger {
+		return nil, fmt.Errorf("matrix market line 1: unsupported field %q, only pattern and integer are supported", header[3])
+	}
+	symmetry := header[4]
+	if symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric" {
+		return nil, fmt.Errorf("matrix market line 1: unsupported symmetry %q", symmetry)
+	}
+
+	// skip the comments and read the size line
+	var fields []string
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		fields = strings.Fields(text)
+		break
+	}
+	if fields == nil {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("matrix market: missing size line")
+	}
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("matrix market line %v: expected \"rows cols entries\" but found %q", line, scanner.Text())
+	}
+	size := make([]int, 3)
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("matrix market line %v: invalid size value %q", line, f)
+		}
+		size[i] = v
+	}
+	rows, cols, entries := size[0], size[1], size[2]
+	if symmetry != "general" && rows != cols {
+		return nil, fmt.Errorf("matrix market line %v: %v matrix must be square found %vx%v", line, symmetry, rows, cols)
+	}
+
+	valueFields := 2
+	if field == MatrixMarketInteger {
+		valueFields = 3
+	}
+
+	data := make([][]int, rows)
+	for i := range data {
+		data[i] = make([]int, 0)
+	}
+	count := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+		if count == entries {
+			return nil, fmt.Errorf("matrix market line %v: more than the %v declared entries", line, entries)
+		}
+		count++
+
+		fields = strings.Fields(text)
+		if len(fields) != valueFields {
+			return nil, fmt.Errorf("matrix market line %v: expected %v values but found %v", line, valueFields, len(fields))
+		}
+		i, err := strconv.Atoi(fields[0])
+		if err != nil || i < 1 || i > rows {
+			return nil, fmt.Errorf("matrix market line %v: row index %q out of range [1-%v]", line, fields[0], rows)
+		}
+		j, err := strconv.Atoi(fields[1])
+		if err != nil || j < 1 || j > cols {
+			return nil, fmt.Errorf("matrix market line %v: column index %q out of range [1-%v]", line, fields[1], cols)
+		}
+
+		if field == MatrixMarketInteger {
+			v, err := strconv.ParseInt(fields[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("matrix market line %v: invalid integer value %q", line, fields[2])
+			}
+			if binaryOnly && v != 0 && v != 1 {
+				return nil, fmt.Errorf("matrix market line %v: value %v is not binary", line, v)
+			}
+			if v%2 == 0 {
+				continue
+			}
+		}
+
+		data[i-1] = append(data[i-1], j-1)
+		if symmetry != "general" && i != j {
+			data[j-1] = append(data[j-1], i-1)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if count != entries {
+		return nil, fmt.Errorf("matrix market: expected %v entries but found %v", entries, count)
+	}
+
+	// duplicate entries are summed, so pairs cancel out
+	for i, row := range data {
+		sort.Ints(row)
+		kept := row[:0]
+		for _, j := range row {
+			if len(kept) > 0 && kept[len(kept)-1] == j {
+				kept = kept[:len(kept)-1]
+				continue
+			}
+			kept = append(kept, j)
+		}
+		data[i] = kept
+	}
+
+	return csrMatFromIndices(rows, cols, data), nil
+}
+
+// WriteMatrixMarket writes m in the Matrix Market coordinate format with general
+// symmetry using the given field, listing the nonzero entries row by row.
+func WriteMatrixMarket(writer io.Writer, m SparseMat, field MatrixMarketField) error {
+	if field != MatrixMarketPattern && field != MatrixMarketInteger {
+		return fmt.Errorf("matrix market: unsupported field %q", field)
+	}
+
+	rows, cols := m.Dims()
+	data := make([][]int, rows)
+	entries := 0
+	for i := range data {
+		data[i] = m.Row(i).NonzeroArray()
+		entries += len(data[i])
+	}
+
+	w := bufio.NewWriter(writer)
+	fmt.Fprintf(w, "%v matrix coordinate %v general\n", matrixMarketBanner, field)
+	fmt.Fprintf(w, "%v %v %v\n", rows, cols, entries)
+	for i, row := range data {
+		for _, j := range row {
+			if field == MatrixMarketInteger {
+				fmt.Fprintf(w, "%v %v 1\n", i+1, j+1)
+			} else {
+				fmt.Fprintf(w, "%v %v\n", i+1, j+1)
+			}
+		}
+	}
+	return w.Flush()
+}
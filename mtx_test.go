@@ -0,0 +1,99 @@
+package sparsemat
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	tests := []struct {
+		input      string
+		binaryOnly bool
+		expected   SparseMat
+	}{
+		{"%%MatrixMarket matrix coordinate pattern general\n% comment\n2 3 3\n1 1\n1 2\n2 3\n", false, CSRMat(2, 3, 1, 1, 0, 0, 0, 1)},
+		{"%%MatrixMarket matrix coordinate integer general\n2 3 4\n1 1 3\n1 2 2\n2 3 -1\n2 2 0\n", false, CSRMat(2, 3, 1, 0, 0, 0, 0, 1)},
+		{"%%MatrixMarket matrix coordinate integer general\n2 2 2\n1 1 1\n2 2 0\n", true, CSRMat(2, 2, 1, 0, 0, 0)},
+		{"%%MatrixMarket matrix coordinate pattern symmetric\n3 3 3\n1 1\n2 1\n3 2\n", false, CSRMat(3, 3, 1, 1, 0, 1, 0, 1, 0, 1, 0)},
+		{"%%MatrixMarket matrix coordinate integer skew-symmetric\n3 3 2\n2 1 -1\n3 1 2\n", false, CSRMat(3, 3, 0, 1, 0, 1, 0, 0, 0, 0, 0)},
+		{"%%MatrixMarket matrix coordinate pattern general\n2 2 3\n1 1\n1 1\n2 2\n", false, CSRMat(2, 2, 0, 0, 0, 1)},
+		{"%%matrixmarket MATRIX Coordinate Pattern General\n\n1 1 1\n\n1 1\n", false, CSRMat(1, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, err := ReadMatrixMarket(strings.NewReader(test.input), test.binaryOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestReadMatrixMarket_malformed(t *testing.T) {
+	tests := []struct {
+		input      string
+		binaryOnly bool
+		err        string
+	}{
+		{"", false, "empty file"},
+		{"%%MatrixMarket matrix array integer general\n", false, "only matrix coordinate"},
+		{"%%MatrixMarket matrix coordinate real general\n", false, "unsupported field"},
+		{"%%MatrixMarket matrix coordinate pattern hermitian\n", false, "unsupported symmetry"},
+		{"%%MatrixMarket matrix coordinate pattern general\n", false, "missing size line"},
+		{"%%MatrixMarket matrix coordinate pattern symmetric\n2 3 0\n", false, "must be square"},
+		{"%%MatrixMarket matrix coordinate pattern general\n2 2 2\n1 1\n", false, "expected 2 entries but found 1"},
+		{"%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 1\n2 2\n", false, "more than the 1 declared"},
+		{"%%MatrixMarket matrix coordinate pattern general\n2 2 1\n3 1\n", false, "row index"},
+		{"%%MatrixMarket matrix coordinate pattern general\n2 2 1\n1 0\n", false, "column index"},
+		{"%%MatrixMarket matrix coordinate integer general\n2 2 1\n1 1\n", false, "expected 3 values"},
+		{"%%MatrixMarket matrix coordinate integer general\n2 2 1\n1 1 2\n", true, "not binary"},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, err := ReadMatrixMarket(strings.NewReader(test.input), test.binaryOnly)
+			if err == nil {
+				t.Fatalf("expected error containing %q", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q but found %q", test.err, err)
+			}
+		})
+	}
+}
+
+func TestWriteMatrixMarket(t *testing.T) {
+	tests := []struct {
+		m        SparseMat
+		field    MatrixMarketField
+		expected string
+	}{
+		{CSRMat(2, 3, 1, 1, 0, 0, 0, 1), MatrixMarketPattern, "%%MatrixMarket matrix coordinate pattern general\n2 3 3\n1 1\n1 2\n2 3\n"},
+		{DOKMat(2, 2, 0, 1, 1, 0), MatrixMarketInteger, "%%MatrixMarket matrix coordinate integer general\n2 2 2\n1 2 1\n2 1 1\n"},
+		{randomMatrix(10, 20), MatrixMarketPattern, ""},
+		{randomMatrix(20, 10), MatrixMarketInteger, ""},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := WriteMatrixMarket(buff, test.m, test.field); err != nil {
+				t.Fatal(err)
+			}
+			if test.expected != "" && buff.String() != test.expected {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, buff.String())
+			}
+
+			actual, err := ReadMatrixMarket(buff, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.m) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.m, actual)
+			}
+		})
+	}
+}
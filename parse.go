@@ -0,0 +1,79 @@
+package sparsemat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseRows splits s into rows separated by newlines, '/' or ';' and returns the
+// nonzero indices and the length of every nonempty row, rows without any value are
+// dropped. Within a row the values may be separated by whitespace or commas or
+// written next to each other.
+func parseRows(s string) (rows [][]int, lengths []int, err error) {
+	lines := strings.FieldsFunc(s, func(r rune) bool {
+		return r == '\n' || r == '/' || r == ';'
+	})
+
+	for _, line := range lines {
+		indices := make([]int, 0)
+		length := 0
+		for _, r := range line {
+			switch r {
+			case '0':
+				length++
+			case '1':
+				indices = append(indices, length)
+				length++
+			case ' ', '\t', '\r', ',':
+			default:
+				return nil, nil, fmt.Errorf("row %v: invalid character %q, only 0 and 1 are allowed", len(rows), r)
+			}
+		}
+		if length == 0 {
+			continue
+		}
+		rows = append(rows, indices)
+		lengths = append(lengths, length)
+	}
+	return rows, lengths, nil
+}
+
+// ParseMat creates a CSR matrix from a grid of 0's and 1's such as "1 0 1 / 0 1 1".
+// Rows are separated by newlines, '/' or ';' and values by whitespace or commas,
+// so the output of String() can be parsed back. All rows must have the same length.
+// An input without any value, such as "", is the 0x0 matrix. Matrices with rows
+// but no columns print as "" too, so they are read back as the 0x0 matrix.
+func ParseMat(s string) (SparseMat, error) {
+	rows, lengths, err := parseRows(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return csrMat(0, 0), nil
+	}
+
+	for i, l := range lengths {
+		if l != lengths[0] {
+			return nil, fmt.Errorf("row %v has %v values but row 0 has %v", i, l, lengths[0])
+		}
+	}
+	return csrMatFromIndices(len(rows), lengths[0], rows), nil
+}
+
+// ParseVec creates a CSR vector from a single row of 0's and 1's such as "1 0 1",
+// using the same rules as ParseMat. An input without any value, such as "", is the
+// vector of length 0.
+func ParseVec(s string) (SparseVector, error) {
+	rows, lengths, err := parseRows(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return CSRVec(0), nil
+	}
+
+	if len(rows) != 1 {
+		return nil, fmt.Errorf("vector must have a single row found %v", len(rows))
+	}
+	return &CSRVector{length: lengths[0], indices: rows[0]}, nil
+}
@@ -0,0 +1,100 @@
+package sparsemat
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseMat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SparseMat
+	}{
+		{"1 0 1 / 0 1 1", CSRMat(2, 3, 1, 0, 1, 0, 1, 1)},
+		{"1 0 1\n0 1 1\n", CSRMat(2, 3, 1, 0, 1, 0, 1, 1)},
+		{"101;011", CSRMat(2, 3, 1, 0, 1, 0, 1, 1)},
+		{"1,0,1\r\n0,1,1\r\n", CSRMat(2, 3, 1, 0, 1, 0, 1, 1)},
+		{"\n\n  1  0  \n\n  0  0  \n", CSRMat(2, 2, 1, 0, 0, 0)},
+		{CSRIdentity(4).String(), CSRIdentity(4)},
+		{DOKMat(2, 3, 0, 1, 1, 1, 0, 0).String(), CSRMat(2, 3, 0, 1, 1, 1, 0, 0)},
+		{"", CSRMat(0, 0)},
+		{" / \n", CSRMat(0, 0)},
+		{CSRMat(3, 0).String(), CSRMat(0, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, err := ParseMat(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseMat_String(t *testing.T) {
+	m := randomMatrix(20, 30)
+	actual, err := ParseMat(m.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !actual.Equals(m) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", m, actual)
+	}
+}
+
+func TestParseMat_invalid(t *testing.T) {
+	tests := []string{
+		"1 0 1 / 0 1",
+		"1 0 2",
+		"1 x 1",
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if m, err := ParseMat(test); err == nil {
+				t.Fatalf("expected an error but found \n%v\n", m)
+			}
+		})
+	}
+}
+
+func TestParseVec(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SparseVector
+	}{
+		{"1 0 1", CSRVec(3, 1, 0, 1)},
+		{"0001", CSRVec(4, 0, 0, 0, 1)},
+		{CSRVec(5, 0, 1, 1, 0, 1).String(), CSRVec(5, 0, 1, 1, 0, 1)},
+		{DOKVec(2, 1, 1).String(), CSRVec(2, 1, 1)},
+		{"", CSRVec(0)},
+		{CSRVec(0).String(), CSRVec(0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, err := ParseVec(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseVec_invalid(t *testing.T) {
+	tests := []string{
+		"1 0 / 0 1",
+		"1 0 -1",
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if v, err := ParseVec(test); err == nil {
+				t.Fatalf("expected an error but found %v", v)
+			}
+		})
+	}
+}
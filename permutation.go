@@ -0,0 +1,125 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Permutation is a permutation of [0,len(p)) that maps index i to p[i]. Applied
+// to a vector it moves the value at index i to index p[i].
+type Permutation []int
+
+// IdentityPermutation returns the permutation of size n that maps every index to itself.
+func IdentityPermutation(n int) Permutation {
+	p := make(Permutation, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// RandomPermutation returns a uniformly random permutation of size n drawn from r,
+// when r is nil the default source of math/rand is used.
+func RandomPermutation(n int, r *rand.Rand) Permutation {
+	if r == nil {
+		return rand.Perm(n)
+	}
+	return r.Perm(n)
+}
+
+// BlockPermutation returns the permutation of a rows x (n/rows) block interleaver,
+// which writes n values row by row and reads them back column by column.
+func BlockPermutation(n, rows int) Permutation {
+	if rows <= 0 || n%rows != 0 {
+		panic(fmt.Sprintf("length %v is not a multiple of the %v rows", n, rows))
+	}
+
+	cols := n / rows
+	p := make(Permutation, n)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			p[r*cols+c] = c*rows + r
+		}
+	}
+	return p
+}
+
+// IsValid returns true if p maps [0,len(p)) one-to-one onto itself.
+func (p Permutation) IsValid() bool {
+	seen := make([]bool, len(p))
+	for _, v := range p {
+		if v < 0 || v >= len(p) || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
+
+// check panics if p is not a valid permutation of size n.
+func (p Permutation) check(n int) {
+	if len(p) != n {
+		panic(fmt.Sprintf("permutation size %v does not match expected %v", len(p), n))
+	}
+	if !p.IsValid() {
+		panic(fmt.Sprintf("%v is not a permutation", []int(p)))
+	}
+}
+
+// Compose returns the permutation applying q first and then p, that is the
+// permutation mapping i to p[q[i]].
+func (p Permutation) Compose(q Permutation) Permutation {
+	p.check(len(q))
+	q.check(len(p))
+
+	r := make(Permutation, len(p))
+	for i, v := range q {
+		r[i] = p[v]
+	}
+	return r
+}
+
+// Inverse returns the permutation undoing p.
+func (p Permutation) Inverse() Permutation {
+	p.check(len(p))
+
+	inv := make(Permutation, len(p))
+	for i, v := range p {
+		inv[v] = i
+	}
+	return inv
+}
+
+// Cycles returns the cycle decomposition of p without its fixed points. Each
+// cycle starts at its smallest index and is followed by the indices it is mapped
+// to, the cycles are ordered by their smallest index.
+func (p Permutation) Cycles() [][]int {
+	p.check(len(p))
+
+	cycles := make([][]int, 0)
+	seen := make([]bool, len(p))
+	for i := range p {
+		if seen[i] || p[i] == i {
+			continue
+		}
+		cycle := make([]int, 0)
+		for j := i; !seen[j]; j = p[j] {
+			seen[j] = true
+			cycle = append(cycle, j)
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles
+}
+
+// Matrix returns the permutation matrix P of p, with P[p[i]][i] = 1, so that
+// P*x is x permuted by p.
+func (p Permutation) Matrix() SparseMat {
+	p.check(len(p))
+
+	m := csrMat(len(p), len(p))
+	for i, v := range p {
+		m.data[v] = []int{i}
+	}
+	return m
+}
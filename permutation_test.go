@@ -0,0 +1,117 @@
+package sparsemat
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestPermutation_IsValid(t *testing.T) {
+	tests := []struct {
+		p        Permutation
+		expected bool
+	}{
+		{Permutation{}, true},
+		{IdentityPermutation(4), true},
+		{Permutation{2, 0, 1}, true},
+		{Permutation{0, 0, 1}, false},
+		{Permutation{0, 3, 1}, false},
+		{Permutation{-1, 0}, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := test.p.IsValid(); actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPermutation_Compose(t *testing.T) {
+	p := Permutation{1, 2, 0}
+	q := Permutation{0, 2, 1}
+
+	expected := Permutation{1, 0, 2}
+	if actual := p.Compose(q); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+
+	// composing matches multiplying the permutation matrices
+	m := CSRMat(3, 3)
+	m.Mul(p.Matrix(), q.Matrix())
+	if !m.Equals(p.Compose(q).Matrix()) {
+		t.Fatalf("expected %v but found %v", p.Compose(q).Matrix(), m)
+	}
+}
+
+func TestPermutation_Inverse(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			p := RandomPermutation(20, r)
+			if !p.IsValid() {
+				t.Fatalf("expected a valid permutation found %v", p)
+			}
+			if actual := p.Compose(p.Inverse()); !reflect.DeepEqual(actual, IdentityPermutation(20)) {
+				t.Fatalf("expected the identity but found %v", actual)
+			}
+			if actual := p.Inverse().Compose(p); !reflect.DeepEqual(actual, IdentityPermutation(20)) {
+				t.Fatalf("expected the identity but found %v", actual)
+			}
+		})
+	}
+}
+
+func TestPermutation_Cycles(t *testing.T) {
+	tests := []struct {
+		p        Permutation
+		expected [][]int
+	}{
+		{IdentityPermutation(3), [][]int{}},
+		{Permutation{1, 0, 2}, [][]int{{0, 1}}},
+		{Permutation{2, 3, 4, 1, 0, 5}, [][]int{{0, 2, 4}, {1, 3}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := test.p.Cycles(); !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPermutation_Matrix(t *testing.T) {
+	p := Permutation{2, 0, 1}
+
+	expected := CSRMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0)
+	if actual := p.Matrix(); !actual.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+
+	vec := CSRVec(3, 1, 1, 0)
+	actual := CSRVec(3)
+	actual.MatMul(p.Matrix(), vec)
+	if permuted := CSRVecCopy(vec).Permute(p); !actual.Equals(permuted) {
+		t.Fatalf("expected %v but found %v", actual, permuted)
+	}
+}
+
+func TestBlockPermutation(t *testing.T) {
+	tests := []struct {
+		n, rows  int
+		expected Permutation
+	}{
+		{6, 2, Permutation{0, 2, 4, 1, 3, 5}},
+		{6, 3, Permutation{0, 3, 1, 4, 2, 5}},
+		{4, 1, IdentityPermutation(4)},
+		{4, 4, IdentityPermutation(4)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := BlockPermutation(test.n, test.rows); !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// Poly is a polynomial over GF(2). A Poly is never modified, all operations
+// return a new one. The zero value is the zero polynomial.
+type Poly struct {
+	p gf2Poly
+}
+
+// NewPoly returns the sum of the monomials x^e for the given exponents, so
+// NewPoly(0, 1, 4) is x^4 + x + 1. An exponent given twice cancels out.
+func NewPoly(exponents ...int) Poly {
+	max := -1
+	for _, e := range exponents {
+		if e < 0 {
+			panic(fmt.Sprintf("exponent must be >= 0 found %v", e))
+		}
+		if e > max {
+			max = e
+		}
+	}
+
+	p := make(gf2Poly, max/64+1)
+	for _, e := range exponents {
+		p[e/64] ^= 1 << uint(e%64)
+	}
+	return Poly{p.trim()}
+}
+
+// PolyFromVec returns the polynomial whose coefficient of x^i is vec[i].
+func PolyFromVec(vec SparseVector) Poly {
+	return NewPoly(vec.NonzeroArray()...)
+}
+
+// Vec returns the coefficients of p as a vector of the given length, index i
+// holding the coefficient of x^i. The length must be greater than the degree.
+func (p Poly) Vec(length int) SparseVector {
+	if length <= p.Degree() {
+		panic(fmt.Sprintf("polynomial of degree %v does not fit in length %v", p.Degree(), length))
+	}
+	return &CSRVector{length: length, indices: p.Exponents()}
+}
+
+// Exponents returns the exponents with a nonzero coefficient in increasing order.
+func (p Poly) Exponents() []int {
+	return p.p.indices()
+}
+
+// Degree returns the degree of p, -1 for the zero polynomial.
+func (p Poly) Degree() int {
+	return p.p.deg()
+}
+
+// Coeff returns the coefficient of x^i.
+func (p Poly) Coeff(i int) int {
+	return int(p.p.coeff(i))
+}
+
+// Weight returns the number of nonzero coefficients.
+func (p Poly) Weight() int {
+	w := 0
+	for _, word := range p.p {
+		w += bits.OnesCount64(word)
+	}
+	return w
+}
+
+func (p Poly) IsZero() bool {
+	return p.p.isZero()
+}
+
+func (p Poly) IsOne() bool {
+	return p.p.isOne()
+}
+
+func (p Poly) Equals(q Poly) bool {
+	return p.p.equals(q.p)
+}
+
+// String returns p written in decreasing powers of x, e.g. "x^4 + x + 1".
+func (p Poly) String() string {
+	exponents := p.Exponents()
+	if len(exponents) == 0 {
+		return "0"
+	}
+
+	terms := make([]string, 0, len(exponents))
+	for k := len(exponents) - 1; k >= 0; k-- {
+		switch e := exponents[k]; e {
+		case 0:
+			terms = append(terms, "1")
+		case 1:
+			terms = append(terms, "x")
+		default:
+			terms = append(terms, fmt.Sprintf("x^%v", e))
+		}
+	}
+	return strings.Join(terms, " + ")
+}
+
+// Add returns p + q, which over GF(2) is also p - q.
+func (p Poly) Add(q Poly) Poly {
+	return Poly{p.p.add(q.p)}
+}
+
+// Mul returns p * q.
+func (p Poly) Mul(q Poly) Poly {
+	return Poly{p.p.mul(q.p)}
+}
+
+// DivMod returns the quotient and remainder of p divided by the nonzero q.
+func (p Poly) DivMod(q Poly) (quo, rem Poly) {
+	quo.p, rem.p = p.p.divMod(q.p)
+	return
+}
+
+// Mod returns the remainder of p divided by the nonzero q.
+func (p Poly) Mod(q Poly) Poly {
+	return Poly{p.p.mod(q.p)}
+}
+
+// Gcd returns the greatest common divisor of p and q.
+func (p Poly) Gcd(q Poly) Poly {
+	return Poly{p.p.gcd(q.p)}
+}
+
+// ExtGcd returns the greatest common divisor g of p and q along with s and t such
+// that s*p + t*q = g.
+func (p Poly) ExtGcd(q Poly) (g, s, t Poly) {
+	g, s, t = p, NewPoly(0), Poly{}
+	r, s1, t1 := q, Poly{}, NewPoly(0)
+	for !r.IsZero() {
+		quo, rem := g.DivMod(r)
+		g, r = r, rem
+		s, s1 = s1, s.Add(quo.Mul(s1))
+		t, t1 = t1, t.Add(quo.Mul(t1))
+	}
+	return
+}
+
+// Lcm returns the least common multiple of the nonzero p and q.
+func (p Poly) Lcm(q Poly) Poly {
+	return Poly{p.p.lcm(q.p)}
+}
+
+// MulMod returns p * q mod m.
+func (p Poly) MulMod(q, m Poly) Poly {
+	return Poly{p.p.mulMod(q.p, m.p)}
+}
+
+// PowMod returns p^e mod m for e >= 0.
+func (p Poly) PowMod(e *big.Int, m Poly) Poly {
+	if e.Sign() < 0 {
+		panic(fmt.Sprintf("exponent must be >= 0 found %v", e))
+	}
+	return Poly{p.p.powMod(e, m.p)}
+}
+
+// InverseMod returns the inverse of p modulo m and true, or false when p and m
+// are not coprime.
+func (p Poly) InverseMod(m Poly) (Poly, bool) {
+	g, s, _ := p.Mod(m).ExtGcd(m)
+	if !g.IsOne() {
+		return Poly{}, false
+	}
+	return s.Mod(m), true
+}
+
+// frobenius returns x^(2^k) mod p.
+func (p Poly) frobenius(k int) Poly {
+	h := NewPoly(1).Mod(p)
+	for ; k > 0; k-- {
+		h = h.MulMod(h, p)
+	}
+	return h
+}
+
+// IsIrreducible returns true if p has degree at least 1 and no factor of lower
+// positive degree. This is Rabin's test: p of degree n is irreducible if and only
+// if it divides x^(2^n) - x and is coprime to x^(2^(n/q)) - x for every prime q
+// dividing n.
+func (p Poly) IsIrreducible() bool {
+	n := p.Degree()
+	if n < 1 {
+		return false
+	}
+
+	x := NewPoly(1)
+	if !p.frobenius(n).Equals(x.Mod(p)) {
+		return false
+	}
+	for _, q := range primeFactors(big.NewInt(int64(n))) {
+		if !p.frobenius(n / int(q.Int64())).Add(x).Gcd(p).IsOne() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPrimitive returns true if p is irreducible of degree n and x has order 2^n-1
+// modulo p, so the powers of x are all the nonzero elements of GF(2^n) and a
+// linear feedback shift register with feedback polynomial p has maximal period.
+// This needs the prime factors of 2^n-1, which can be slow to find for large n.
+func (p Poly) IsPrimitive() bool {
+	if !p.IsIrreducible() {
+		return false
+	}
+	if p.Coeff(0) == 0 {
+		// x itself
+		return false
+	}
+
+	n := p.Degree()
+	order := new(big.Int).Lsh(bigOne, uint(n))
+	order.Sub(order, bigOne)
+
+	x := NewPoly(1)
+	q := new(big.Int)
+	for _, f := range mersenneFactors(n, map[int][]*big.Int{}) {
+		if x.PowMod(q.Quo(order, f), p).IsOne() {
+			return false
+		}
+	}
+	return true
+}
+
+// Circulant returns the n x n circulant matrix of p, whose row i holds the
+// coefficients of x^i * p mod x^n + 1, that is the coefficients of p rotated right
+// by i. Products and sums of circulants of size n match those of their
+// polynomials modulo x^n + 1.
+func (p Poly) Circulant(n int) *CirculantMatrix {
+	if n <= 0 {
+		panic(fmt.Sprintf("circulant size must be > 0 found %v", n))
+	}
+	return CirculantMat(p.Mod(NewPoly(0, n)).Vec(n))
+}
@@ -0,0 +1,170 @@
+package sparsemat
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestNewPoly(t *testing.T) {
+	tests := []struct {
+		exponents []int
+		expected  string
+		degree    int
+	}{
+		{[]int{}, "0", -1},
+		{[]int{0}, "1", 0},
+		{[]int{4, 1, 0}, "x^4 + x + 1", 4},
+		{[]int{1, 3, 1}, "x^3", 3},
+		{[]int{0, 64, 200}, "x^200 + x^64 + 1", 200},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			p := NewPoly(test.exponents...)
+			if p.String() != test.expected || p.Degree() != test.degree {
+				t.Fatalf("expected %v of degree %v but found %v of degree %v", test.expected, test.degree, p, p.Degree())
+			}
+		})
+	}
+}
+
+func TestPoly_Vec(t *testing.T) {
+	p := NewPoly(0, 2, 3)
+	vec := p.Vec(5)
+	if !vec.Equals(CSRVec(5, 1, 0, 1, 1, 0)) {
+		t.Fatalf("expected %v but found %v", CSRVec(5, 1, 0, 1, 1, 0), vec)
+	}
+	if q := PolyFromVec(DOKVecCopy(vec)); !q.Equals(p) {
+		t.Fatalf("expected %v but found %v", p, q)
+	}
+}
+
+func TestPoly_DivMod(t *testing.T) {
+	tests := []struct {
+		a, b     Poly
+		quo, rem Poly
+	}{
+		{NewPoly(0, 2), NewPoly(0, 1), NewPoly(0, 1), NewPoly()},
+		{NewPoly(0, 1, 3), NewPoly(0, 1), NewPoly(1, 2), NewPoly(0)},
+		{NewPoly(1), NewPoly(0, 2), NewPoly(), NewPoly(1)},
+		{NewPoly(0, 70, 130), NewPoly(0, 65), NewPoly(0, 5, 65), NewPoly(5)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			quo, rem := test.a.DivMod(test.b)
+			if !quo.Equals(test.quo) || !rem.Equals(test.rem) {
+				t.Fatalf("expected %v, %v but found %v, %v", test.quo, test.rem, quo, rem)
+			}
+			if !quo.Mul(test.b).Add(rem).Equals(test.a) {
+				t.Fatalf("expected quo*b+rem to be %v", test.a)
+			}
+		})
+	}
+}
+
+func TestPoly_Gcd(t *testing.T) {
+	// (x+1)(x^2+x+1) and (x+1)^2
+	a, b := NewPoly(0, 3), NewPoly(0, 2)
+	if actual := a.Gcd(b); !actual.Equals(NewPoly(0, 1)) {
+		t.Fatalf("expected x + 1 but found %v", actual)
+	}
+	if actual := a.Lcm(b); !actual.Equals(NewPoly(0, 1, 3, 4)) {
+		t.Fatalf("expected x^4 + x^3 + x + 1 but found %v", actual)
+	}
+}
+
+func TestPoly_ExtGcd(t *testing.T) {
+	tests := []struct {
+		a, b Poly
+		gcd  Poly
+	}{
+		{NewPoly(0, 3), NewPoly(0, 2), NewPoly(0, 1)},
+		{NewPoly(0, 1, 4), NewPoly(1, 2), NewPoly(0)},
+		{NewPoly(), NewPoly(0, 1), NewPoly(0, 1)},
+		{NewPoly(0, 5, 80, 130), NewPoly(0, 3, 64), NewPoly(0, 5, 80, 130).Gcd(NewPoly(0, 3, 64))},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			g, s, u := test.a.ExtGcd(test.b)
+			if !g.Equals(test.gcd) {
+				t.Fatalf("expected gcd %v but found %v", test.gcd, g)
+			}
+			if !s.Mul(test.a).Add(u.Mul(test.b)).Equals(g) {
+				t.Fatalf("expected (%v)(%v) + (%v)(%v) = %v", s, test.a, u, test.b, g)
+			}
+		})
+	}
+}
+
+func TestPoly_InverseMod(t *testing.T) {
+	m := NewPoly(0, 1, 4)
+	for e := 1; e < 16; e++ {
+		p := NewPoly(1).PowMod(big.NewInt(int64(e)), m)
+		inv, ok := p.InverseMod(m)
+		if !ok || !p.MulMod(inv, m).IsOne() {
+			t.Fatalf("expected an inverse of %v found %v", p, inv)
+		}
+	}
+	if _, ok := NewPoly(0, 1).InverseMod(NewPoly(0, 2)); ok {
+		t.Fatalf("expected x + 1 to have no inverse modulo x^2 + 1")
+	}
+}
+
+func TestPoly_PowMod(t *testing.T) {
+	m := NewPoly(0, 1, 4)
+	x := NewPoly(1)
+	if actual := x.PowMod(big.NewInt(15), m); !actual.IsOne() {
+		t.Fatalf("expected x^15 = 1 mod %v found %v", m, actual)
+	}
+	if actual := x.PowMod(big.NewInt(5), m); actual.IsOne() {
+		t.Fatalf("expected x^5 != 1 mod %v", m)
+	}
+}
+
+func TestPoly_IsIrreducible(t *testing.T) {
+	tests := []struct {
+		p                      Poly
+		irreducible, primitive bool
+	}{
+		{NewPoly(), false, false},
+		{NewPoly(0), false, false},
+		{NewPoly(1), true, false},
+		{NewPoly(0, 1), true, true},
+		{NewPoly(0, 2), false, false},
+		{NewPoly(0, 1, 2), true, true},
+		{NewPoly(0, 1, 4), true, true},
+		{NewPoly(0, 1, 2, 3, 4), true, false},
+		{NewPoly(0, 4, 5), false, false},
+		{NewPoly(0, 2, 6), false, false},
+		{NewPoly(0, 1, 3, 4, 64), true, true},
+		{NewPoly(0, 1, 127), true, true},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if actual := test.p.IsIrreducible(); actual != test.irreducible {
+				t.Fatalf("expected %v to be irreducible %v", test.p, test.irreducible)
+			}
+			if actual := test.p.IsPrimitive(); actual != test.primitive {
+				t.Fatalf("expected %v to be primitive %v", test.p, test.primitive)
+			}
+		})
+	}
+}
+
+func TestPoly_Circulant(t *testing.T) {
+	expected := CSRMat(3, 3, 1, 1, 0, 0, 1, 1, 1, 0, 1)
+	if actual := NewPoly(0, 1).Circulant(3); !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\nbut found\n%v", expected, actual)
+	}
+	if actual := NewPoly(0, 4).Circulant(3); !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\nbut found\n%v", expected, actual)
+	}
+
+	// circulant products follow the polynomial products mod x^n + 1
+	a, b := NewPoly(0, 2, 5), NewPoly(1, 3, 4, 6)
+	product := CSRMat(7, 7)
+	product.Mul(a.Circulant(7), b.Circulant(7))
+	if expected := a.MulMod(b, NewPoly(0, 7)).Circulant(7); !product.Equals(expected) {
+		t.Fatalf("expected \n%v\nbut found\n%v", expected, product)
+	}
+}
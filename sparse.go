@@ -3,12 +3,15 @@ package sparsemat
 type SparseVector interface {
 	Add(a, b SparseVector) SparseVector
 	And(a, b SparseVector) SparseVector
+	Append(a SparseVector) SparseVector
 	At(i int) int
 	Dot(a SparseVector) int
 	Equals(v SparseVector) bool
+	FirstSet() (index int, has bool)
 	HammingDistance(a SparseVector) int
 	HammingWeight() int
 	IsZero() bool
+	LastSet() (index int, has bool)
 	Len() int
 	MarshalJSON() ([]byte, error)
 	MatMul(mat SparseMat, vec SparseVector) SparseVector
@@ -18,10 +21,20 @@ type SparseVector interface {
 	NonzeroArray() (indices []int)
 	NextSet(startingIndex int) (index int, has bool)
 	Or(a, b SparseVector) SparseVector
+	Permute(p Permutation) SparseVector
+	PrevSet(startingIndex int) (index int, has bool)
+	Rank(i int) int
+	Reverse() SparseVector
+	RotateLeft(k int) SparseVector
+	RotateRight(k int) SparseVector
+	Select(k int) (index int, has bool)
 	Set(i, value int) SparseVector
 	SetVec(a SparseVector, i int) SparseVector
+	ShiftLeft(k int) SparseVector
+	ShiftRight(k int) SparseVector
 	Slice(i, length int) SparseVector
 	String() string
+	Truncate(length int) SparseVector
 	XOr(a, b SparseVector) SparseVector
 	UnmarshalJSON(bytes []byte) error
 }
@@ -30,19 +43,33 @@ type SparseMat interface {
 	Add(a, b SparseMat) SparseMat
 	AddRows(i1, i2, dest int) SparseMat
 	And(a, b SparseMat) SparseMat
+	AppendRow(vec SparseVector) SparseMat
 	At(i, j int) int
 	Column(j int) SparseVector
+	DeleteColumns(cols ...int) SparseMat
+	DeleteRows(rows ...int) SparseMat
 	Dims() (int, int)
 	Equals(m SparseMat) bool
+	InsertColumns(j, count int) SparseMat
+	InsertRows(i, count int) SparseMat
 	MarshalJSON() ([]byte, error)
 	Mul(a, b SparseMat) SparseMat
 	Negate() SparseMat
 	Or(a, b SparseMat) SparseMat
+	PermuteColumns(p Permutation) SparseMat
+	PermuteRows(p Permutation) SparseMat
+	Resize(rows, cols int) SparseMat
 	Row(i int) SparseVector
 	Set(i, j, value int) SparseMat
+	SelectColumns(idx []int) SparseMat
+	SelectColumnsMask(mask SparseVector) SparseMat
+	SelectRows(idx []int) SparseMat
+	SelectRowsMask(mask SparseVector) SparseMat
 	SetColumn(j int, vec SparseVector) SparseMat
+	SetColumns(idx []int, a SparseMat) SparseMat
 	SetMatrix(a SparseMat, iOffset, jOffset int) SparseMat
 	SetRow(i int, vec SparseVector) SparseMat
+	SetRows(idx []int, a SparseMat) SparseMat
 	Slice(i, j, rows, cols int) SparseMat
 	String() string
 	SwapRows(i1, i2 int) SparseMat
@@ -0,0 +1,102 @@
+package sparsemat
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// spyCounts returns for every pixel of a width x height image the number of
+// nonzero entries of m falling into it. When the matrix is smaller than the image
+// every entry covers a block of pixels.
+func spyCounts(m SparseMat, width, height int) (counts [][]int, max int) {
+	if width <= 0 || height <= 0 {
+		panic(fmt.Sprintf("image size must be > 0 found (%v,%v)", width, height))
+	}
+
+	rows, cols := m.Dims()
+	counts = make([][]int, height)
+	for y := range counts {
+		counts[y] = make([]int, width)
+	}
+
+	span := func(i, n, size int) (int, int) {
+		start := int(int64(i) * int64(size) / int64(n))
+		end := int(int64(i+1) * int64(size) / int64(n))
+		if end <= start {
+			end = start + 1
+		}
+		return start, end
+	}
+
+	for i := 0; i < rows; i++ {
+		y0, y1 := span(i, rows, height)
+		for _, j := range m.Row(i).NonzeroArray() {
+			x0, x1 := span(j, cols, width)
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					counts[y][x]++
+					if counts[y][x] > max {
+						max = counts[y][x]
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// spyLightest is the lightest shade of a pixel covering any nonzero entry, it keeps
+// isolated entries visible next to dense areas.
+const spyLightest = 200
+
+// Spy renders the sparsity pattern of m into a width x height grayscale image with
+// the nonzero entries drawn dark on a white background. When the matrix is larger
+// than the image each pixel is shaded by the number of nonzero entries it covers,
+// relative to the pixel covering the most, from spyLightest down to black for the
+// densest areas. Only pixels without any nonzero entry are white.
+func Spy(m SparseMat, width, height int) *image.Gray {
+	counts, max := spyCounts(m, width, height)
+
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y, row := range counts {
+		for x, c := range row {
+			shade := uint8(255)
+			if c > 0 {
+				shade = uint8(spyLightest * (max - c) / max)
+			}
+			img.SetGray(x, y, color.Gray{Y: shade})
+		}
+	}
+	return img
+}
+
+// WriteSpyPNG writes the image created by Spy to w as a PNG.
+func WriteSpyPNG(w io.Writer, m SparseMat, width, height int) error {
+	return png.Encode(w, Spy(m, width, height))
+}
+
+// WriteSpyPBM writes the sparsity pattern of m to w as a width x height binary PBM
+// (P4) image. PBM has no shading so a pixel is black when it covers any nonzero entry.
+func WriteSpyPBM(w io.Writer, m SparseMat, width, height int) error {
+	counts, _ := spyCounts(m, width, height)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P4\n%v %v\n", width, height)
+	line := make([]byte, (width+7)/8)
+	for _, row := range counts {
+		for k := range line {
+			line[k] = 0
+		}
+		for x, c := range row {
+			if c > 0 {
+				line[x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+		bw.Write(line)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,96 @@
+package sparsemat
+
+import (
+	"bytes"
+	"image/png"
+	"strconv"
+	"testing"
+)
+
+func TestSpy(t *testing.T) {
+	tests := []struct {
+		m             SparseMat
+		width, height int
+		expected      [][]uint8
+	}{
+		{CSRIdentity(2), 2, 2, [][]uint8{{0, 255}, {255, 0}}},
+		{CSRIdentity(2), 4, 2, [][]uint8{{0, 0, 255, 255}, {255, 255, 0, 0}}},
+		{DOKMat(2, 4, 1, 1, 1, 0, 0, 0, 0, 0), 2, 1, [][]uint8{{0, 100}}},
+		{CSRMat(4, 4, 1, 1, 0, 0, 1, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0), 2, 2, [][]uint8{{0, 255}, {255, 150}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			img := Spy(test.m, test.width, test.height)
+			if img.Bounds().Dx() != test.width || img.Bounds().Dy() != test.height {
+				t.Fatalf("expected size (%v,%v) but found %v", test.width, test.height, img.Bounds())
+			}
+			for y, row := range test.expected {
+				for x, expected := range row {
+					if actual := img.GrayAt(x, y).Y; actual != expected {
+						t.Fatalf("expected %v at (%v,%v) but found %v", expected, x, y, actual)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSpy_isolated(t *testing.T) {
+	// a dense 50x50 block and a single entry in the opposite corner
+	m := CSRMat(100, 100)
+	for i := 0; i < 50; i++ {
+		for j := 0; j < 50; j++ {
+			m.Set(i, j, 1)
+		}
+	}
+	m.Set(99, 99, 1)
+
+	img := Spy(m, 10, 10)
+	if actual := img.GrayAt(0, 0).Y; actual != 0 {
+		t.Fatalf("expected the dense block to be 0 but found %v", actual)
+	}
+	if actual := img.GrayAt(9, 9).Y; actual > spyLightest {
+		t.Fatalf("expected the isolated entry to be at most %v but found %v", spyLightest, actual)
+	}
+	if actual := img.GrayAt(9, 0).Y; actual != 255 {
+		t.Fatalf("expected an empty pixel to be 255 but found %v", actual)
+	}
+}
+
+func TestWriteSpyPNG(t *testing.T) {
+	buff := &bytes.Buffer{}
+	if err := WriteSpyPNG(buff, randomMatrix(100, 300), 30, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 30 || img.Bounds().Dy() != 10 {
+		t.Fatalf("expected size (30,10) but found %v", img.Bounds())
+	}
+}
+
+func TestWriteSpyPBM(t *testing.T) {
+	tests := []struct {
+		m             SparseMat
+		width, height int
+		expected      []byte
+	}{
+		{CSRIdentity(2), 2, 2, []byte("P4\n2 2\n\x80\x40")},
+		{CSRMat(1, 10, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1), 10, 1, []byte("P4\n10 1\n\x80\x40")},
+		{CSRMat(2, 2), 2, 1, []byte("P4\n2 1\n\x00")},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := WriteSpyPBM(buff, test.m, test.width, test.height); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buff.Bytes(), test.expected) {
+				t.Fatalf("expected %q but found %q", test.expected, buff.Bytes())
+			}
+		})
+	}
+}
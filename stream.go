@@ -0,0 +1,259 @@
+package sparsemat
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MatEncoder writes matrices to a stream one row at a time, producing the same
+// JSON as CSRMatrix.MarshalJSON without building the whole document in memory.
+type MatEncoder struct {
+	w          *bufio.Writer
+	rows, cols int
+	written    int
+	open       bool
+}
+
+// NewMatEncoder returns an encoder that writes to w.
+func NewMatEncoder(w io.Writer) *MatEncoder {
+	return &MatEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the JSON encoding of m followed by a newline, the same way
+// json.Encoder would write the output of MarshalJSON.
+func (enc *MatEncoder) Encode(m SparseMat) error {
+	rows, cols := m.Dims()
+	if err := enc.WriteHeader(rows, cols); err != nil {
+		return err
+	}
+	for i := 0; i < rows; i++ {
+		if err := enc.WriteRow(m.Row(i)); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// WriteHeader starts a matrix with the given dimensions, it must be followed by
+// exactly rows calls to WriteRow and then Close.
+func (enc *MatEncoder) WriteHeader(rows, cols int) error {
+	if enc.open {
+		return fmt.Errorf("matrix encoder: header written twice")
+	}
+	if rows < 0 || cols < 0 {
+		return fmt.Errorf("matrix encoder: invalid dimensions (%v,%v)", rows, cols)
+	}
+
+	enc.rows, enc.cols = rows, cols
+	enc.written = 0
+	enc.open = true
+	_, err := fmt.Fprintf(enc.w, `{"Rows":%v,"Cols":%v,"Data":[`, rows, cols)
+	return err
+}
+
+// WriteRow writes the next row of the matrix.
+func (enc *MatEncoder) WriteRow(vec SparseVector) error {
+	if !enc.open {
+		return fmt.Errorf("matrix encoder: row written before header")
+	}
+	if enc.written == enc.rows {
+		return fmt.Errorf("matrix encoder: more than the %v declared rows", enc.rows)
+	}
+	if vec.Len() != enc.cols {
+		return fmt.Errorf("matrix encoder: row length %v does not match %v cols", vec.Len(), enc.cols)
+	}
+
+	buf := make([]byte, 0, 64)
+	if enc.written > 0 {
+		buf = append(buf, ',')
+	}
+	buf = append(buf, '[')
+	for k, j := range vec.NonzeroArray() {
+		if k > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendInt(buf, int64(j), 10)
+	}
+	buf = append(buf, ']')
+
+	enc.written++
+	_, err := enc.w.Write(buf)
+	return err
+}
+
+// Close ends the current matrix and flushes it to the underlying writer.
+func (enc *MatEncoder) Close() error {
+	if !enc.open {
+		return fmt.Errorf("matrix encoder: close before header")
+	}
+	if enc.written != enc.rows {
+		return fmt.Errorf("matrix encoder: expected %v rows but %v were written", enc.rows, enc.written)
+	}
+
+	enc.open = false
+	if _, err := enc.w.WriteString("]}\n"); err != nil {
+		return err
+	}
+	return enc.w.Flush()
+}
+
+// MatDecoder reads matrices written by MatEncoder or MarshalJSON from a stream
+// one row at a time.
+type MatDecoder struct {
+	dec        *json.Decoder
+	rows, cols int
+	read       int
+	open       bool
+}
+
+// NewMatDecoder returns a decoder that reads from r.
+func NewMatDecoder(r io.Reader) *MatDecoder {
+	return &MatDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next matrix from the stream as a CSR matrix.
+func (dec *MatDecoder) Decode() (SparseMat, error) {
+	rows, cols, err := dec.ReadHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	// the row count comes from the stream, so rows are only allocated as they are read
+	m := csrMat(0, cols)
+	for {
+		row, err := dec.ReadRow()
+		if err == io.EOF {
+			m.rows = rows
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.data = append(m.data, row.(*CSRVector).indices)
+	}
+}
+
+func (dec *MatDecoder) expectDelim(delim json.Delim) error {
+	t, err := dec.dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("matrix decoder: expected %v but found %v", delim, t)
+	}
+	return nil
+}
+
+// ReadHeader reads the start of the next matrix and returns its dimensions.
+// The Rows and Cols fields must come before the Data field.
+func (dec *MatDecoder) ReadHeader() (rows, cols int, err error) {
+	if dec.open {
+		return 0, 0, fmt.Errorf("matrix decoder: rows of the previous matrix not read")
+	}
+	if err = dec.expectDelim('{'); err != nil {
+		return
+	}
+
+	hasRows, hasCols := false, false
+	for dec.dec.More() {
+		var t json.Token
+		if t, err = dec.dec.Token(); err != nil {
+			return
+		}
+		key, _ := t.(string)
+
+		switch {
+		case strings.EqualFold(key, "Rows"):
+			err = dec.dec.Decode(&rows)
+			hasRows = true
+		case strings.EqualFold(key, "Cols"):
+			err = dec.dec.Decode(&cols)
+			hasCols = true
+		case strings.EqualFold(key, "Data"):
+			if !hasRows || !hasCols {
+				return 0, 0, fmt.Errorf("matrix decoder: Rows and Cols must come before Data")
+			}
+			if rows < 0 || cols < 0 {
+				return 0, 0, fmt.Errorf("matrix decoder: invalid dimensions (%v,%v)", rows, cols)
+			}
+			if err = dec.expectDelim('['); err != nil {
+				return
+			}
+			dec.rows, dec.cols, dec.read = rows, cols, 0
+			dec.open = true
+			return
+		default:
+			var skip json.RawMessage
+			err = dec.dec.Decode(&skip)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return 0, 0, fmt.Errorf("matrix decoder: missing Data")
+}
+
+// ReadRow reads the next row of the current matrix. After the last row it
+// returns io.EOF and the decoder is ready for the next matrix.
+func (dec *MatDecoder) ReadRow() (SparseVector, error) {
+	if !dec.open {
+		return nil, fmt.Errorf("matrix decoder: row read before header")
+	}
+
+	if dec.read == dec.rows {
+		if dec.dec.More() {
+			return nil, fmt.Errorf("matrix decoder: more than the %v declared rows", dec.rows)
+		}
+		if err := dec.expectDelim(']'); err != nil {
+			return nil, err
+		}
+		for dec.dec.More() {
+			var skip json.RawMessage
+			if _, err := dec.dec.Token(); err != nil {
+				return nil, err
+			}
+			if err := dec.dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+		if err := dec.expectDelim('}'); err != nil {
+			return nil, err
+		}
+		dec.open = false
+		return nil, io.EOF
+	}
+
+	if !dec.dec.More() {
+		return nil, fmt.Errorf("matrix decoder: expected %v rows but found %v", dec.rows, dec.read)
+	}
+	var indices []int
+	if err := dec.dec.Decode(&indices); err != nil {
+		return nil, err
+	}
+	if indices == nil {
+		indices = make([]int, 0)
+	}
+	if err := checkIndices(indices, dec.cols); err != nil {
+		return nil, fmt.Errorf("matrix decoder: row %v %v", dec.read, err)
+	}
+
+	dec.read++
+	return &CSRVector{length: dec.cols, indices: indices}, nil
+}
+
+// checkIndices returns an error unless the indices are sorted, unique and in [0,length).
+func checkIndices(indices []int, length int) error {
+	for k, i := range indices {
+		if i < 0 || i >= length {
+			return fmt.Errorf("index %v out of range: [0-%v]", i, length-1)
+		}
+		if k > 0 && indices[k-1] >= i {
+			return fmt.Errorf("indices %v and %v are not sorted and unique", indices[k-1], i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package sparsemat
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestMatEncoder(t *testing.T) {
+	tests := []struct {
+		m SparseMat
+	}{
+		{CSRIdentity(3)},
+		{CSRMat(2, 3)},
+		{CSRMat(0, 3)},
+		{DOKMatCopy(randomMatrix(20, 30))},
+		{randomMatrix(50, 10)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			buff := &bytes.Buffer{}
+			if err := NewMatEncoder(buff).Encode(test.m); err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := json.Marshal(CSRMatCopy(test.m))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != string(expected)+"\n" {
+				t.Fatalf("expected %s but found %s", expected, buff.String())
+			}
+
+			actual, err := NewMatDecoder(buff).Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.m) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.m, actual)
+			}
+		})
+	}
+}
+
+func TestMatEncoder_rows(t *testing.T) {
+	buff := &bytes.Buffer{}
+	enc := NewMatEncoder(buff)
+	if err := enc.WriteRow(CSRVec(2)); err == nil {
+		t.Fatalf("expected an error writing a row before the header")
+	}
+	if err := enc.WriteHeader(2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.WriteRow(CSRVec(3, 1, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.WriteRow(CSRVec(2)); err == nil {
+		t.Fatalf("expected an error writing a row of the wrong length")
+	}
+	if err := enc.Close(); err == nil {
+		t.Fatalf("expected an error closing with missing rows")
+	}
+	if err := enc.WriteRow(DOKVec(3, 0, 1, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.WriteRow(CSRVec(3)); err == nil {
+		t.Fatalf("expected an error writing too many rows")
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a second matrix in the same stream
+	if err := enc.Encode(CSRIdentity(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := NewMatDecoder(buff)
+	first, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Equals(CSRMat(2, 3, 1, 0, 1, 0, 1, 0)) {
+		t.Fatalf("unexpected first matrix \n%v\n", first)
+	}
+	second, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Equals(CSRIdentity(2)) {
+		t.Fatalf("unexpected second matrix \n%v\n", second)
+	}
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Fatalf("expected EOF but found %v", err)
+	}
+}
+
+func TestMatDecoder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected SparseMat
+	}{
+		{`{"Rows":2,"Cols":2,"Data":[[0],[1]]}`, CSRIdentity(2)},
+		{` { "rows" : 2 , "cols" : 2 , "Other": {"a": [1]}, "data" : [ [0,1] , null ] , "More": 1 } `, CSRMat(2, 2, 1, 1, 0, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, err := NewMatDecoder(strings.NewReader(test.input)).Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatDecoder_malformed(t *testing.T) {
+	tests := []struct {
+		input string
+	}{
+		{``},
+		{`[]`},
+		{`{"Data":[[0]],"Rows":1,"Cols":1}`},
+		{`{"Rows":1,"Cols":1}`},
+		{`{"Rows":2,"Cols":2,"Data":[[0]]}`},
+		{`{"Rows":1,"Cols":2,"Data":[[0],[1]]}`},
+		{`{"Rows":1,"Cols":2,"Data":[[2]]}`},
+		{`{"Rows":1,"Cols":2,"Data":[[1,0]]}`},
+		{`{"Rows":1,"Cols":2,"Data":[[1,1]]}`},
+		{`{"Rows":-1,"Cols":2,"Data":[]}`},
+		{`{"Rows":100000000000000,"Cols":3,"Data":[]}`},
+		{`{"Rows":1,"Cols":2,"Data":[["a"]]}`},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if m, err := NewMatDecoder(strings.NewReader(test.input)).Decode(); err == nil {
+				t.Fatalf("expected an error but found \n%v\n", m)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package sparsemat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// The structured matrices (circulant and Toeplitz) only store what defines them.
+// In place updates are applied to a copy and kept only if the result is still
+// structured, otherwise they panic. Operations changing the shape always panic,
+// and operations whose result is not structured return a new CSR matrix.
+
+func structureBroken(kind, op string) {
+	panic(fmt.Sprintf("%v breaks the %v structure", op, kind))
+}
+
+// toCSR returns a CSR copy of m built row by row.
+func toCSR(m SparseMat) *CSRMatrix {
+	rows, cols := m.Dims()
+	return csrMatFromIndices(rows, cols, matRows(m))
+}
+
+// tableString returns the same representation as the String method of the CSR
+// and DOK matrices.
+func tableString(rows, cols int, at func(i, j int) int) string {
+	buff := &strings.Builder{}
+	table := tablewriter.NewWriter(buff)
+
+	table.SetBorder(false)
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+
+	for i := 0; i < rows; i++ {
+		row := make([]string, cols)
+		for j := 0; j < cols; j++ {
+			row[j] = fmt.Sprint(at(i, j))
+		}
+		table.Append(row)
+	}
+
+	table.Render()
+	return buff.String()
+}
+
+// equalRows returns true if a and b have the same shape and rows.
+func equalRows(a, b SparseMat) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	aRows, aCols := a.Dims()
+	bRows, bCols := b.Dims()
+	if aRows != bRows || aCols != bCols {
+		return false
+	}
+	for i := 0; i < aRows; i++ {
+		if !a.Row(i).Equals(b.Row(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkElementwise panics unless a and b are not nil and both have the shape (rows,cols).
+func checkElementwise(op string, rows, cols int, a, b SparseMat) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+
+	aRows, aCols := a.Dims()
+	bRows, bCols := b.Dims()
+	if aRows != bRows || aCols != bCols {
+		panic(fmt.Sprintf("%v shape misalignment both inputs must be equal found (%v,%v) and (%v,%v)", op, aRows, aCols, bRows, bCols))
+	}
+	if rows != aRows || cols != aCols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", rows, cols, aRows, aCols))
+	}
+}
+
+// checkMul panics unless a and b are not nil, are not mat and their product has
+// the shape (rows,cols).
+func checkMul(mat, a, b SparseMat, rows, cols int) {
+	if a == nil || b == nil {
+		panic("multiply input was found to be nil")
+	}
+	if mat == a || mat == b {
+		panic("multiply self assignment not allowed")
+	}
+
+	aRows, aCols := a.Dims()
+	bRows, bCols := b.Dims()
+	if aCols != bRows {
+		panic(fmt.Sprintf("multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", aRows, aCols, bRows, bCols))
+	}
+	if rows != aRows || cols != bCols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", rows, cols, aRows, bCols))
+	}
+}
+
+func equalIndices(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func andIndices(a, b []int) []int {
+	result := make([]int, 0)
+	for ai, bi := 0, 0; ai < len(a) && bi < len(b); {
+		switch {
+		case a[ai] < b[bi]:
+			ai++
+		case a[ai] > b[bi]:
+			bi++
+		default:
+			result = append(result, a[ai])
+			ai++
+			bi++
+		}
+	}
+	return result
+}
+
+func orIndices(a, b []int) []int {
+	// the union is the sum plus the intersection, which are disjoint
+	return addRows(addRows(a, b), andIndices(a, b))
+}
+
+// complementIndices returns the indices in [0,length) missing from the sorted a.
+func complementIndices(a []int, length int) []int {
+	result := make([]int, 0, length-len(a))
+	k := 0
+	for i := 0; i < length; i++ {
+		if k < len(a) && a[k] == i {
+			k++
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+// elementwiseRows returns the rows of a and b combined by combine.
+func elementwiseRows(a, b SparseMat, combine func(x, y []int) []int) [][]int {
+	aRows := matRows(a)
+	bRows := matRows(b)
+	for i := range aRows {
+		aRows[i] = combine(aRows[i], bRows[i])
+	}
+	return aRows
+}
+
+var (
+	_ SparseMat = &CirculantMatrix{}
+	_ SparseMat = &ToeplitzMatrix{}
+)
@@ -0,0 +1,125 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The tagged JSON envelope records the storage format next to the data written
+// by the MarshalJSON method of the value, for example:
+//
+//	{"format":"csr","data":{"Rows":2,"Cols":2,"Data":[[0],[1]]}}
+type taggedJSON struct {
+	Format string          `json:"format"`
+	Data   json.RawMessage `json:"data"`
+}
+
+const (
+	formatCSR = "csr"
+	formatDOK = "dok"
+
+	formatCirculant = "circulant"
+	formatToeplitz  = "toeplitz"
+)
+
+func marshalTagged(format string, value json.Marshaler) ([]byte, error) {
+	data, err := value.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(taggedJSON{Format: format, Data: data})
+}
+
+func unmarshalTagged(bytes []byte) (taggedJSON, error) {
+	var tagged taggedJSON
+	if err := json.Unmarshal(bytes, &tagged); err != nil {
+		return tagged, err
+	}
+	if tagged.Format == "" {
+		return tagged, fmt.Errorf("missing format tag")
+	}
+	if len(tagged.Data) == 0 {
+		return tagged, fmt.Errorf("missing data for format %q", tagged.Format)
+	}
+	return tagged, nil
+}
+
+// MarshalSparseMat encodes m as JSON inside an envelope tagged with its storage
+// format so it can be decoded by UnmarshalSparseMat without knowing its type.
+func MarshalSparseMat(m SparseMat) ([]byte, error) {
+	switch m.(type) {
+	case *CSRMatrix:
+		return marshalTagged(formatCSR, m)
+	case *DOKMatrix:
+		return marshalTagged(formatDOK, m)
+	case *CirculantMatrix:
+		return marshalTagged(formatCirculant, m)
+	case *ToeplitzMatrix:
+		return marshalTagged(formatToeplitz, m)
+	}
+	return nil, fmt.Errorf("unsupported matrix type %T", m)
+}
+
+// UnmarshalSparseMat decodes a matrix written by MarshalSparseMat into a matrix of
+// the tagged storage format. The indices are checked to be sorted, unique and in range.
+func UnmarshalSparseMat(bytes []byte) (SparseMat, error) {
+	tagged, err := unmarshalTagged(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var m SparseMat
+	switch tagged.Format {
+	case formatCSR:
+		m = &CSRMatrix{}
+	case formatDOK:
+		m = &DOKMatrix{}
+	case formatCirculant:
+		m = &CirculantMatrix{}
+	case formatToeplitz:
+		m = &ToeplitzMatrix{}
+	default:
+		return nil, fmt.Errorf("unknown matrix format %q", tagged.Format)
+	}
+
+	if err = m.UnmarshalJSON(tagged.Data); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MarshalSparseVector encodes vec as JSON inside an envelope tagged with its
+// storage format so it can be decoded by UnmarshalSparseVector without knowing its type.
+func MarshalSparseVector(vec SparseVector) ([]byte, error) {
+	switch vec.(type) {
+	case *CSRVector:
+		return marshalTagged(formatCSR, vec)
+	case *DOKVector:
+		return marshalTagged(formatDOK, vec)
+	}
+	return nil, fmt.Errorf("unsupported vector type %T", vec)
+}
+
+// UnmarshalSparseVector decodes a vector written by MarshalSparseVector into a vector
+// of the tagged storage format. The indices are checked to be sorted, unique and in range.
+func UnmarshalSparseVector(bytes []byte) (SparseVector, error) {
+	tagged, err := unmarshalTagged(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var vec SparseVector
+	switch tagged.Format {
+	case formatCSR:
+		vec = &CSRVector{}
+	case formatDOK:
+		vec = &DOKVector{}
+	default:
+		return nil, fmt.Errorf("unknown vector format %q", tagged.Format)
+	}
+
+	if err = vec.UnmarshalJSON(tagged.Data); err != nil {
+		return nil, err
+	}
+	return vec, nil
+}
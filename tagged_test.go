@@ -0,0 +1,136 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestSparseMat_taggedJSON(t *testing.T) {
+	tests := []struct {
+		m        SparseMat
+		expected string
+	}{
+		{CSRIdentity(2), `{"format":"csr","data":{"Rows":2,"Cols":2,"Data":[[0],[1]]}}`},
+		{DOKIdentity(2), `{"format":"dok","data":{"Rows":2,"Cols":2,"Data":[[0],[1]]}}`},
+		{randomMatrix(10, 20), ""},
+		{DOKMatCopy(randomMatrix(10, 20)), ""},
+		{CirculantMat(CSRVec(3, 0, 1, 1)), `{"format":"circulant","data":{"Size":3,"Row":[1,2]}}`},
+		{ToeplitzMat(CSRVec(3, 1, 0, 1), CSRVec(2, 1, 1)), `{"format":"toeplitz","data":{"Rows":2,"Cols":3,"Row":[0,2],"Column":[0,1]}}`},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			bs, err := MarshalSparseMat(test.m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.expected != "" && string(bs) != test.expected {
+				t.Fatalf("expected %v but found %s", test.expected, bs)
+			}
+
+			actual, err := UnmarshalSparseMat(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.m) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.m, actual)
+			}
+			if fmt.Sprintf("%T", actual) != fmt.Sprintf("%T", test.m) {
+				t.Fatalf("expected a %T but found %T", test.m, actual)
+			}
+		})
+	}
+}
+
+func TestSparseVector_taggedJSON(t *testing.T) {
+	tests := []struct {
+		v        SparseVector
+		expected string
+	}{
+		{CSRVec(3, 1, 0, 1), `{"format":"csr","data":{"Length":3,"Indices":[0,2]}}`},
+		{DOKVec(3, 1, 0, 1), `{"format":"dok","data":{"Length":3,"Values":{"0":1,"2":1}}}`},
+		{randomCSRVector(50), ""},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			bs, err := MarshalSparseVector(test.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.expected != "" && string(bs) != test.expected {
+				t.Fatalf("expected %v but found %s", test.expected, bs)
+			}
+
+			actual, err := UnmarshalSparseVector(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equals(test.v) {
+				t.Fatalf("expected %v but found %v", test.v, actual)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSparseMat_invalid(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`{"data":{"Rows":1,"Cols":1,"Data":[[0]]}}`,
+		`{"format":"csr"}`,
+		`{"format":"coo","data":{"Rows":1,"Cols":1,"Data":[[0]]}}`,
+		`{"format":"csr","data":{"Rows":2,"Cols":1,"Data":[[0]]}}`,
+		`{"format":"csr","data":{"Rows":1,"Cols":1,"Data":[[1]]}}`,
+		`{"format":"dok","data":{"Rows":1,"Cols":3,"Data":[[2,1]]}}`,
+		`{"format":"dok","data":{"Rows":1,"Cols":3,"Data":[[1,1]]}}`,
+		`{"format":"csr","data":{"Rows":1,"Cols":-3,"Data":[[]]}}`,
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if m, err := UnmarshalSparseMat([]byte(test)); err == nil {
+				t.Fatalf("expected an error but found \n%v\n", m)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSparseVector_invalid(t *testing.T) {
+	tests := []string{
+		`{"format":"csr","data":{"Length":3,"Indices":[3]}}`,
+		`{"format":"csr","data":{"Length":3,"Indices":[2,0]}}`,
+		`{"format":"csr","data":{"Length":-1}}`,
+		`{"format":"dok","data":{"Length":3,"Values":{"5":1}}}`,
+		`{"format":"dok","data":{"Length":3,"Values":{"1":0}}}`,
+		`{"format":"bits","data":{"Length":3}}`,
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			if v, err := UnmarshalSparseVector([]byte(test)); err == nil {
+				t.Fatalf("expected an error but found %v", v)
+			}
+		})
+	}
+}
+
+func TestSparseMat_taggedJSONField(t *testing.T) {
+	type config struct {
+		H json.RawMessage
+	}
+	bs, _ := MarshalSparseMat(DOKIdentity(3))
+	doc, err := json.Marshal(config{H: bs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c config
+	if err = json.Unmarshal(doc, &c); err != nil {
+		t.Fatal(err)
+	}
+	m, err := UnmarshalSparseMat(c.H)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Equals(DOKIdentity(3)) {
+		t.Fatalf("expected identity but found \n%v\n", m)
+	}
+}
@@ -0,0 +1,473 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ToeplitzMatrix is a matrix that is constant along each diagonal, so m[i][j] only
+// depends on j-i. Only the first row and the first column are stored.
+//
+// Updates in place, like Set or SetRow, panic unless the result is still
+// Toeplitz. Updates changing the shape always panic.
+type ToeplitzMatrix struct {
+	rows, cols int
+	row, col   *CSRVector
+}
+
+type toeplitzMatrix struct {
+	Rows, Cols int
+	Row        []int
+	Column     []int
+}
+
+func (mat *ToeplitzMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toeplitzMatrix{
+		Rows:   mat.rows,
+		Cols:   mat.cols,
+		Row:    mat.row.indices,
+		Column: mat.col.indices,
+	})
+}
+
+func (mat *ToeplitzMatrix) UnmarshalJSON(bytes []byte) error {
+	var m toeplitzMatrix
+	err := json.Unmarshal(bytes, &m)
+	if err != nil {
+		return err
+	}
+
+	if m.Rows <= 0 || m.Cols <= 0 {
+		return fmt.Errorf("invalid matrix shape (%v,%v)", m.Rows, m.Cols)
+	}
+	if m.Row == nil {
+		m.Row = make([]int, 0)
+	}
+	if m.Column == nil {
+		m.Column = make([]int, 0)
+	}
+	if err = checkIndices(m.Row, m.Cols); err != nil {
+		return fmt.Errorf("row %v", err)
+	}
+	if err = checkIndices(m.Column, m.Rows); err != nil {
+		return fmt.Errorf("column %v", err)
+	}
+	row := &CSRVector{length: m.Cols, indices: m.Row}
+	col := &CSRVector{length: m.Rows, indices: m.Column}
+	if row.at(0) != col.at(0) {
+		return fmt.Errorf("row and column disagree on the first element")
+	}
+
+	mat.rows = m.Rows
+	mat.cols = m.Cols
+	mat.row = row
+	mat.col = col
+	return nil
+}
+
+// ToeplitzMat creates a new Toeplitz matrix with the given first row and first
+// column, which must agree on their first element. The matrix has as many rows
+// as the length of the column and as many columns as the length of the row.
+func ToeplitzMat(row, col SparseVector) *ToeplitzMatrix {
+	if row.Len() <= 0 || col.Len() <= 0 {
+		panic(fmt.Sprintf("toeplitz shape must be > 0 found (%v,%v)", col.Len(), row.Len()))
+	}
+	if row.At(0) != col.At(0) {
+		panic("toeplitz row and column must agree on the first element")
+	}
+	return &ToeplitzMatrix{
+		rows: col.Len(),
+		cols: row.Len(),
+		row:  &CSRVector{length: row.Len(), indices: row.NonzeroArray()},
+		col:  &CSRVector{length: col.Len(), indices: col.NonzeroArray()},
+	}
+}
+
+// FirstRow returns a copy of the first row.
+func (mat *ToeplitzMatrix) FirstRow() SparseVector {
+	return CSRVecCopy(mat.row)
+}
+
+// FirstColumn returns a copy of the first column.
+func (mat *ToeplitzMatrix) FirstColumn() SparseVector {
+	return CSRVecCopy(mat.col)
+}
+
+// diagonals returns, in increasing order, the offsets j-i of the nonzero diagonals.
+func (mat *ToeplitzMatrix) diagonals() []int {
+	offsets := make([]int, 0, len(mat.col.indices)+len(mat.row.indices))
+	for k := len(mat.col.indices) - 1; k >= 0; k-- {
+		if i := mat.col.indices[k]; i > 0 {
+			offsets = append(offsets, -i)
+		}
+	}
+	return append(offsets, mat.row.indices...)
+}
+
+// isUpper returns true if the matrix is upper triangular, all diagonals below the
+// main one are 0.
+func (mat *ToeplitzMatrix) isUpper() bool {
+	return len(mat.col.indices) == 0 || len(mat.col.indices) == 1 && mat.col.indices[0] == 0
+}
+
+// isLower returns true if the matrix is lower triangular, all diagonals above the
+// main one are 0.
+func (mat *ToeplitzMatrix) isLower() bool {
+	return len(mat.row.indices) == 0 || len(mat.row.indices) == 1 && mat.row.indices[0] == 0
+}
+
+// setDefining replaces the first row and column by the given sorted indices.
+func (mat *ToeplitzMatrix) setDefining(row, col []int) {
+	mat.row = &CSRVector{length: mat.cols, indices: row}
+	mat.col = &CSRVector{length: mat.rows, indices: col}
+}
+
+func (mat *ToeplitzMatrix) checkRowBounds(i int) {
+	if i < 0 || i >= mat.rows {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.rows-1))
+	}
+}
+
+func (mat *ToeplitzMatrix) checkColBounds(j int) {
+	if j < 0 || j >= mat.cols {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", j, mat.cols-1))
+	}
+}
+
+// Dims returns the dimensions of the matrix.
+func (mat *ToeplitzMatrix) Dims() (int, int) {
+	return mat.rows, mat.cols
+}
+
+// At returns the value at row index i and column index j.
+func (mat *ToeplitzMatrix) At(i, j int) int {
+	mat.checkRowBounds(i)
+	mat.checkColBounds(j)
+
+	if j >= i {
+		return mat.row.at(j - i)
+	}
+	return mat.col.at(i - j)
+}
+
+// Row returns row i.
+func (mat *ToeplitzMatrix) Row(i int) SparseVector {
+	mat.checkRowBounds(i)
+
+	indices := make([]int, 0)
+	for _, o := range mat.diagonals() {
+		if j := i + o; 0 <= j && j < mat.cols {
+			indices = append(indices, j)
+		}
+	}
+	return &CSRVector{length: mat.cols, indices: indices}
+}
+
+// Column returns column j.
+func (mat *ToeplitzMatrix) Column(j int) SparseVector {
+	mat.checkColBounds(j)
+
+	offsets := mat.diagonals()
+	indices := make([]int, 0)
+	for k := len(offsets) - 1; k >= 0; k-- {
+		if i := j - offsets[k]; 0 <= i && i < mat.rows {
+			indices = append(indices, i)
+		}
+	}
+	return &CSRVector{length: mat.rows, indices: indices}
+}
+
+// T returns a new Toeplitz matrix that is the transpose of this matrix.
+func (mat *ToeplitzMatrix) T() SparseMat {
+	return ToeplitzMat(mat.col, mat.row)
+}
+
+// MulVec returns the product of this matrix with the column vector vec. It takes
+// time proportional to the product of the number of nonzero diagonals and the
+// weight of vec.
+func (mat *ToeplitzMatrix) MulVec(vec SparseVector) SparseVector {
+	if vec.Len() != mat.cols {
+		panic(fmt.Sprintf("vector length %v does not match matrix columns %v", vec.Len(), mat.cols))
+	}
+
+	// entry i sums the diagonal j-i times vec[j]
+	result := dokVec(mat.rows)
+	offsets := mat.diagonals()
+	for _, j := range vec.NonzeroArray() {
+		for _, o := range offsets {
+			if i := j - o; 0 <= i && i < mat.rows {
+				result.set(i, 1-result.at(i))
+			}
+		}
+	}
+	return CSRVecCopy(result)
+}
+
+// Equals return true if the m matrix has the same shape and values as this matrix.
+func (mat *ToeplitzMatrix) Equals(m SparseMat) bool {
+	if t, ok := m.(*ToeplitzMatrix); ok {
+		return mat.rows == t.rows && mat.cols == t.cols && mat.row.Equals(t.row) && mat.col.Equals(t.col)
+	}
+	return equalRows(mat, m)
+}
+
+// String returns a string representation of this matrix.
+func (mat *ToeplitzMatrix) String() string {
+	return tableString(mat.rows, mat.cols, mat.At)
+}
+
+// Slice creates a new Toeplitz matrix containing the slice of data, any
+// contiguous block of a Toeplitz matrix is Toeplitz.
+func (mat *ToeplitzMatrix) Slice(i, j, rows, cols int) SparseMat {
+	if rows <= 0 || cols <= 0 {
+		panic("slice rows and cols must >= 1")
+	}
+
+	mat.checkRowBounds(i)
+	mat.checkColBounds(j)
+	mat.checkRowBounds(i + rows - 1)
+	mat.checkColBounds(j + cols - 1)
+
+	return ToeplitzMat(mat.Row(i).Slice(j, cols), mat.Column(j).Slice(i, rows))
+}
+
+// SelectRows returns a new CSR matrix made of the rows given by idx.
+func (mat *ToeplitzMatrix) SelectRows(idx []int) SparseMat {
+	return toCSR(mat).SelectRows(idx)
+}
+
+// SelectColumns returns a new CSR matrix made of the columns given by idx.
+func (mat *ToeplitzMatrix) SelectColumns(idx []int) SparseMat {
+	return toCSR(mat).SelectColumns(idx)
+}
+
+// SelectRowsMask returns a new CSR matrix made of the rows where mask is 1.
+func (mat *ToeplitzMatrix) SelectRowsMask(mask SparseVector) SparseMat {
+	return toCSR(mat).SelectRowsMask(mask)
+}
+
+// SelectColumnsMask returns a new CSR matrix made of the columns where mask is 1.
+func (mat *ToeplitzMatrix) SelectColumnsMask(mask SparseVector) SparseMat {
+	return toCSR(mat).SelectColumnsMask(mask)
+}
+
+// setRows replaces this matrix by the one with the given rows, which must be Toeplitz.
+func (mat *ToeplitzMatrix) setRows(op string, rows [][]int) {
+	col := make([]int, 0)
+	for i, row := range rows {
+		if len(row) > 0 && row[0] == 0 {
+			col = append(col, i)
+		}
+	}
+	t := &ToeplitzMatrix{
+		rows: mat.rows,
+		cols: mat.cols,
+		row:  &CSRVector{length: mat.cols, indices: rows[0]},
+		col:  &CSRVector{length: mat.rows, indices: col},
+	}
+	for i := 1; i < mat.rows; i++ {
+		if !equalIndices(rows[i], t.Row(i).NonzeroArray()) {
+			panic(fmt.Sprintf("%v result is not toeplitz", op))
+		}
+	}
+	*mat = *t
+}
+
+// elementwise stores combine of a and b, directly on the first rows and columns
+// when both are Toeplitz.
+func (mat *ToeplitzMatrix) elementwise(op string, a, b SparseMat, combine func(x, y []int) []int) SparseMat {
+	checkElementwise(op, mat.rows, mat.cols, a, b)
+
+	at, aOk := a.(*ToeplitzMatrix)
+	bt, bOk := b.(*ToeplitzMatrix)
+	if aOk && bOk {
+		mat.row = &CSRVector{length: mat.cols, indices: combine(at.row.indices, bt.row.indices)}
+		mat.col = &CSRVector{length: mat.rows, indices: combine(at.col.indices, bt.col.indices)}
+		return mat
+	}
+
+	mat.setRows(op, elementwiseRows(a, b, combine))
+	return mat
+}
+
+// Add stores the addition of a and b in this matrix, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) Add(a, b SparseMat) SparseMat {
+	return mat.elementwise("addition", a, b, addRows)
+}
+
+// XOr executes a piecewise logical XOR on the two matrices and stores the values in
+// this matrix, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) XOr(a, b SparseMat) SparseMat {
+	return mat.elementwise("XOR", a, b, addRows)
+}
+
+// And executes a piecewise logical AND on the two matrices and stores the values in
+// this matrix, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) And(a, b SparseMat) SparseMat {
+	return mat.elementwise("AND", a, b, andIndices)
+}
+
+// Or executes a piecewise logical OR on the two matrices and stores the values in
+// this matrix, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) Or(a, b SparseMat) SparseMat {
+	return mat.elementwise("OR", a, b, orIndices)
+}
+
+// Mul multiplies two matrices and stores the values in this matrix, the result must
+// be Toeplitz. This is the case for example for products of upper (or lower)
+// triangular Toeplitz matrices, whose first row (column) is found by multiplying
+// the polynomials of the first rows (columns). Other products are computed row by
+// row and checked.
+func (mat *ToeplitzMatrix) Mul(a, b SparseMat) SparseMat {
+	checkMul(mat, a, b, mat.rows, mat.cols)
+
+	at, aOk := a.(*ToeplitzMatrix)
+	bt, bOk := b.(*ToeplitzMatrix)
+	if aOk && bOk {
+		// the products are truncated by the inner dimension unless the result is
+		// no wider (taller) than it
+		inner := at.cols
+		switch {
+		case at.isUpper() && bt.isUpper() && mat.cols <= inner:
+			row := PolyFromVec(at.row).MulMod(PolyFromVec(bt.row), NewPoly(mat.cols))
+			mat.setDefining(row.Exponents(), andIndices(row.Exponents(), []int{0}))
+			return mat
+		case at.isLower() && bt.isLower() && mat.rows <= inner:
+			col := PolyFromVec(at.col).MulMod(PolyFromVec(bt.col), NewPoly(mat.rows))
+			mat.setDefining(andIndices(col.Exponents(), []int{0}), col.Exponents())
+			return mat
+		}
+	}
+
+	mat.setRows("multiply", mulRows(matRows(a), matRows(b), mat.cols))
+	return mat
+}
+
+// Negate performs a piecewise logical negation.
+func (mat *ToeplitzMatrix) Negate() SparseMat {
+	mat.row = &CSRVector{length: mat.cols, indices: complementIndices(mat.row.indices, mat.cols)}
+	mat.col = &CSRVector{length: mat.rows, indices: complementIndices(mat.col.indices, mat.rows)}
+	return mat
+}
+
+// Zeroize take the current matrix sets all values to 0.
+func (mat *ToeplitzMatrix) Zeroize() SparseMat {
+	mat.row = &CSRVector{length: mat.cols, indices: make([]int, 0)}
+	mat.col = &CSRVector{length: mat.rows, indices: make([]int, 0)}
+	return mat
+}
+
+const toeplitzKind = "toeplitz"
+
+// update applies op to a CSR copy of this matrix and keeps the result, which must
+// be Toeplitz.
+func (mat *ToeplitzMatrix) update(op string, apply func(m *CSRMatrix)) SparseMat {
+	m := toCSR(mat)
+	apply(m)
+	mat.setRows(op, m.data)
+	return mat
+}
+
+// AddRows adds rows i1 and i2 into row dest, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) AddRows(i1, i2, dest int) SparseMat {
+	return mat.update("AddRows", func(m *CSRMatrix) { m.AddRows(i1, i2, dest) })
+}
+
+// AppendRow panics, it would break the Toeplitz structure.
+func (mat *ToeplitzMatrix) AppendRow(vec SparseVector) SparseMat {
+	structureBroken(toeplitzKind, "AppendRow")
+	return mat
+}
+
+// DeleteColumns panics, it would break the Toeplitz structure.
+func (mat *ToeplitzMatrix) DeleteColumns(cols ...int) SparseMat {
+	structureBroken(toeplitzKind, "DeleteColumns")
+	return mat
+}
+
+// DeleteRows panics, it would break the Toeplitz structure.
+func (mat *ToeplitzMatrix) DeleteRows(rows ...int) SparseMat {
+	structureBroken(toeplitzKind, "DeleteRows")
+	return mat
+}
+
+// InsertColumns panics, it would break the Toeplitz structure.
+func (mat *ToeplitzMatrix) InsertColumns(j, count int) SparseMat {
+	structureBroken(toeplitzKind, "InsertColumns")
+	return mat
+}
+
+// InsertRows panics, it would break the Toeplitz structure.
+func (mat *ToeplitzMatrix) InsertRows(i, count int) SparseMat {
+	structureBroken(toeplitzKind, "InsertRows")
+	return mat
+}
+
+// PermuteColumns moves column j to column p[j], the result must be Toeplitz.
+func (mat *ToeplitzMatrix) PermuteColumns(p Permutation) SparseMat {
+	return mat.update("PermuteColumns", func(m *CSRMatrix) { m.PermuteColumns(p) })
+}
+
+// PermuteRows moves row i to row p[i], the result must be Toeplitz.
+func (mat *ToeplitzMatrix) PermuteRows(p Permutation) SparseMat {
+	return mat.update("PermuteRows", func(m *CSRMatrix) { m.PermuteRows(p) })
+}
+
+// Resize panics unless the shape is unchanged, any other shape would break the
+// Toeplitz structure.
+func (mat *ToeplitzMatrix) Resize(rows, cols int) SparseMat {
+	if r, c := mat.Dims(); r != rows || c != cols {
+		structureBroken(toeplitzKind, "Resize")
+	}
+	return mat
+}
+
+// Set sets the value at row index i and column index j, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) Set(i, j, value int) SparseMat {
+	if mat.At(i, j) == value%2 {
+		return mat
+	}
+	return mat.update("Set", func(m *CSRMatrix) { m.Set(i, j, value) })
+}
+
+// SetColumn sets column j to vec, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SetColumn(j int, vec SparseVector) SparseMat {
+	return mat.update("SetColumn", func(m *CSRMatrix) { m.SetColumn(j, vec) })
+}
+
+// SetColumns sets column idx[k] to column k of a, for every k, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SetColumns(idx []int, a SparseMat) SparseMat {
+	return mat.update("SetColumns", func(m *CSRMatrix) { m.SetColumns(idx, a) })
+}
+
+// SetMatrix replaces the values of this matrix with the values of a, with a's
+// origin at (iOffset,jOffset), the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SetMatrix(a SparseMat, iOffset, jOffset int) SparseMat {
+	return mat.update("SetMatrix", func(m *CSRMatrix) { m.SetMatrix(a, iOffset, jOffset) })
+}
+
+// SetRow sets row i to vec, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SetRow(i int, vec SparseVector) SparseMat {
+	return mat.update("SetRow", func(m *CSRMatrix) { m.SetRow(i, vec) })
+}
+
+// SetRows sets row idx[k] to row k of a, for every k, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SetRows(idx []int, a SparseMat) SparseMat {
+	return mat.update("SetRows", func(m *CSRMatrix) { m.SetRows(idx, a) })
+}
+
+// SwapColumns swaps columns j1 and j2, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SwapColumns(j1, j2 int) SparseMat {
+	return mat.update("SwapColumns", func(m *CSRMatrix) { m.SwapColumns(j1, j2) })
+}
+
+// SwapRows swaps rows i1 and i2, the result must be Toeplitz.
+func (mat *ToeplitzMatrix) SwapRows(i1, i2 int) SparseMat {
+	return mat.update("SwapRows", func(m *CSRMatrix) { m.SwapRows(i1, i2) })
+}
+
+// ZeroizeRange sets the values of the given range to 0, the result must be Toeplitz.
+// Zeroizing the whole matrix always is.
+func (mat *ToeplitzMatrix) ZeroizeRange(i, j, rows, cols int) SparseMat {
+	return mat.update("ZeroizeRange", func(m *CSRMatrix) { m.ZeroizeRange(i, j, rows, cols) })
+}
@@ -0,0 +1,199 @@
+package sparsemat
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func randomToeplitz(rows, cols int) *ToeplitzMatrix {
+	row, col := randomCSRVector(cols), randomCSRVector(rows)
+	col.Set(0, row.At(0))
+	return ToeplitzMat(row, col)
+}
+
+func TestToeplitzMat(t *testing.T) {
+	mat := ToeplitzMat(CSRVec(4, 1, 1, 0, 1), CSRVec(3, 1, 0, 1))
+	expected := CSRMat(3, 4,
+		1, 1, 0, 1,
+		0, 1, 1, 0,
+		1, 0, 1, 1,
+	)
+	if !mat.Equals(expected) || !expected.Equals(mat) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, mat)
+	}
+}
+
+func TestToeplitzMatrix_access(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rows, cols := 1+rand.Intn(20), 1+rand.Intn(20)
+			mat := randomToeplitz(rows, cols)
+			expected := CSRMatCopy(mat)
+
+			for r := 0; r < rows; r++ {
+				for c := 0; c < cols; c++ {
+					if r > 0 && c > 0 && mat.At(r, c) != mat.At(r-1, c-1) {
+						t.Fatalf("(%v,%v) is not on a constant diagonal", r, c)
+					}
+				}
+				if !mat.Row(r).Equals(expected.Row(r)) {
+					t.Fatalf("row %v expected %v but found %v", r, expected.Row(r), mat.Row(r))
+				}
+			}
+			for c := 0; c < cols; c++ {
+				if !mat.Column(c).Equals(expected.Column(c)) {
+					t.Fatalf("column %v expected %v but found %v", c, expected.Column(c), mat.Column(c))
+				}
+			}
+			if !mat.T().Equals(expected.T()) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected.T(), mat.T())
+			}
+
+			vec := randomCSRVector(cols)
+			if actual := mat.MulVec(vec); !actual.Equals(CSRVec(mat.rows).MatMul(expected, vec)) {
+				t.Fatalf("expected %v but found %v", CSRVec(mat.rows).MatMul(expected, vec), actual)
+			}
+
+			i, j := rand.Intn(rows), rand.Intn(cols)
+			sliceRows, sliceCols := 1+rand.Intn(rows-i), 1+rand.Intn(cols-j)
+			if actual := mat.Slice(i, j, sliceRows, sliceCols); !actual.Equals(expected.Slice(i, j, sliceRows, sliceCols)) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected.Slice(i, j, sliceRows, sliceCols), actual)
+			}
+		})
+	}
+}
+
+func TestToeplitzMatrix_Mul(t *testing.T) {
+	// upper triangular Toeplitz matrices are closed under multiplication
+	upper := func(n int) *ToeplitzMatrix {
+		row := randomCSRVector(n)
+		col := CSRVec(n)
+		col.Set(0, row.At(0))
+		return ToeplitzMat(row, col)
+	}
+
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			n := 1 + rand.Intn(20)
+			a, b := upper(n), upper(n)
+			expected := CSRMat(n, n).Mul(a, b)
+
+			actual := ToeplitzMat(CSRVec(n), CSRVec(n)).Mul(a, b)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+
+			// and so are the lower triangular ones
+			expected = CSRMat(n, n).Mul(a.T(), b.T())
+			actual = ToeplitzMat(CSRVec(n), CSRVec(n)).Mul(a.T(), b.T())
+			if !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+
+			// other products are checked row by row
+			c := randomToeplitz(n, n)
+			if actual := ToeplitzMat(CSRVec(n), CSRVec(n)).Mul(c, CSRIdentity(n)); !actual.Equals(c) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", c, actual)
+			}
+		})
+	}
+}
+
+func TestToeplitzMatrix_elementwise(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rows, cols := 1+rand.Intn(20), 1+rand.Intn(20)
+			a, b := randomToeplitz(rows, cols), randomToeplitz(rows, cols)
+
+			if actual, expected := ToeplitzMat(CSRVec(cols), CSRVec(rows)).Add(a, b), CSRMat(rows, cols).Add(a, b); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			if actual, expected := ToeplitzMat(CSRVec(cols), CSRVec(rows)).And(a, CSRMatCopy(b)), CSRMat(rows, cols).And(a, b); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			if actual, expected := ToeplitzMat(CSRVec(cols), CSRVec(rows)).Or(a, b), CSRMat(rows, cols).Or(a, b); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+			if actual, expected := CSRMatCopy(a).Negate(), a.Negate(); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestToeplitzMatrix_update(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			rows, cols := 1+rand.Intn(20), 1+rand.Intn(20)
+			mat := randomToeplitz(rows, cols)
+			expected := CSRMatCopy(mat)
+
+			// writes of the current values keep the structure
+			r, c := rand.Intn(rows), rand.Intn(cols)
+			mat.Set(r, c, mat.At(r, c))
+			mat.SetRow(r, mat.Row(r))
+			mat.SetColumn(c, mat.Column(c))
+			mat.SetMatrix(mat.Slice(r, c, rows-r, cols-c), r, c)
+			mat.PermuteRows(IdentityPermutation(rows))
+			if !mat.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, mat)
+			}
+
+			// the corners are diagonals of their own
+			expected.Set(rows-1, 0, 1-expected.At(rows-1, 0))
+			if actual := mat.Set(rows-1, 0, 1-mat.At(rows-1, 0)); !actual.Equals(expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+			}
+
+			if !mat.ZeroizeRange(0, 0, rows, cols).Equals(CSRMat(rows, cols)) {
+				t.Fatalf("expected zero matrix but found \n%v\n", mat)
+			}
+		})
+	}
+}
+
+func TestToeplitzMatrix_panics(t *testing.T) {
+	tests := []func(){
+		func() { ToeplitzMat(CSRVec(3, 1, 0, 0), CSRVec(2, 0, 1)) },
+		func() { ToeplitzMat(CSRVec(0), CSRVec(2)) },
+		func() { ToeplitzMat(CSRVec(3), CSRVec(2)).Set(0, 0, 1) },
+		func() { ToeplitzMat(CSRVec(3), CSRVec(2)).SetRow(1, CSRVec(3, 0, 1, 0)) },
+		func() { ToeplitzMat(CSRVec(3, 1, 0, 0), CSRVec(2, 1, 0)).SwapRows(0, 1) },
+		func() { ToeplitzMat(CSRVec(3), CSRVec(2)).DeleteRows(0) },
+		func() { ToeplitzMat(CSRVec(3), CSRVec(2)).Add(CSRMat(2, 3), CSRMat(2, 3, 1, 0, 0, 0, 0, 0)) },
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			test()
+		})
+	}
+}
+
+func TestToeplitzMatrix_JSON(t *testing.T) {
+	mat := randomToeplitz(10, 20)
+	bs, err := json.Marshal(mat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual ToeplitzMatrix
+	if err = json.Unmarshal(bs, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !actual.Equals(mat) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", mat, &actual)
+	}
+
+	for _, bad := range []string{`{"Rows":0,"Cols":2}`, `{"Rows":2,"Cols":2,"Row":[0],"Column":[]}`, `{"Rows":2,"Cols":2,"Row":[2],"Column":[]}`} {
+		if err = json.Unmarshal([]byte(bad), &actual); err == nil {
+			t.Fatalf("expected an error for %v", bad)
+		}
+	}
+}
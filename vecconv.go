@@ -0,0 +1,205 @@
+package sparsemat
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// BitOrder selects how the indices of a vector map onto the bits of an integer or
+// of packed bytes and words.
+//
+// For a single integer (uint64 or *big.Int) of a vector of length n, LSBFirst maps
+// index i to bit i and MSBFirst maps index i to bit n-1-i, so with MSBFirst the
+// vector reads the same as the integer written in binary.
+//
+// For packed words, index i lives in word i/64. LSBFirst puts it at bit i%64 of that
+// word and MSBFirst at bit 63-i%64. Packed bytes are described by ByteOrder.
+type BitOrder int
+
+const (
+	// LSBFirst maps the lowest index to the least significant bit.
+	LSBFirst BitOrder = iota
+	// MSBFirst maps the lowest index to the most significant bit.
+	MSBFirst
+)
+
+// ByteOrder selects the order of the bytes when a vector is packed into bytes.
+//
+// The bytes of a vector of length n always encode, in (n+7)/8 bytes, the integer
+// returned by VecToBigInt for the same BitOrder: the BitOrder maps the indices onto
+// the bits of that integer and the ByteOrder picks its little-endian or big-endian
+// encoding. Any padding is made of the high bits of the most significant byte. So
+// LSBFirst with BigEndian is the usual packet layout of an integer, and for lengths
+// that are a multiple of 8, MSBFirst with BigEndian puts index i at bit 7-i%8 of
+// byte i/8, the usual order of bits on the wire.
+type ByteOrder int
+
+const (
+	// LittleEndian puts the least significant byte first.
+	LittleEndian ByteOrder = iota
+	// BigEndian puts the most significant byte first.
+	BigEndian
+)
+
+// byteIndex returns the position in a slice of size bytes of byte k, counting from
+// the least significant byte.
+func (order ByteOrder) byteIndex(k, size int) int {
+	switch order {
+	case LittleEndian:
+		return k
+	case BigEndian:
+		return size - 1 - k
+	}
+	panic(fmt.Sprintf("unknown byte order %v", int(order)))
+}
+
+// bit returns the bit position of index i in a field of size bits.
+func (order BitOrder) bit(i, size int) int {
+	switch order {
+	case LSBFirst:
+		return i
+	case MSBFirst:
+		return size - 1 - i
+	}
+	panic(fmt.Sprintf("unknown bit order %v", int(order)))
+}
+
+// CSRVecFromUint64 creates a new vector of the given length, at most 64, from the
+// bits of x. Bits of x past the length must be 0.
+func CSRVecFromUint64(length int, x uint64, order BitOrder) SparseVector {
+	if length < 0 || 64 < length {
+		panic(fmt.Sprintf("length %v out of range: [0-64]", length))
+	}
+	if bits.Len64(x) > length {
+		panic(fmt.Sprintf("value %v does not fit in %v bits", x, length))
+	}
+
+	indices := make([]int, 0, bits.OnesCount64(x))
+	for i := 0; i < length; i++ {
+		if x>>uint(order.bit(i, length))&1 == 1 {
+			indices = append(indices, i)
+		}
+	}
+	return &CSRVector{length: length, indices: indices}
+}
+
+// VecToUint64 returns the vector, of length at most 64, as the bits of an integer.
+func VecToUint64(vec SparseVector, order BitOrder) uint64 {
+	length := vec.Len()
+	if 64 < length {
+		panic(fmt.Sprintf("vector of length %v does not fit in 64 bits", length))
+	}
+
+	var x uint64
+	for _, i := range vec.NonzeroArray() {
+		x |= 1 << uint(order.bit(i, length))
+	}
+	return x
+}
+
+// CSRVecFromBigInt creates a new vector of the given length from the bits of the
+// non-negative x. Bits of x past the length must be 0.
+func CSRVecFromBigInt(length int, x *big.Int, order BitOrder) SparseVector {
+	if x.Sign() < 0 {
+		panic(fmt.Sprintf("value %v must be >= 0", x))
+	}
+	if x.BitLen() > length {
+		panic(fmt.Sprintf("value %v does not fit in %v bits", x, length))
+	}
+
+	indices := make([]int, 0)
+	for i := 0; i < length; i++ {
+		if x.Bit(order.bit(i, length)) == 1 {
+			indices = append(indices, i)
+		}
+	}
+	return &CSRVector{length: length, indices: indices}
+}
+
+// VecToBigInt returns the vector as the bits of a new non-negative integer.
+func VecToBigInt(vec SparseVector, order BitOrder) *big.Int {
+	length := vec.Len()
+	x := new(big.Int)
+	for _, i := range vec.NonzeroArray() {
+		x.SetBit(x, order.bit(i, length), 1)
+	}
+	return x
+}
+
+// CSRVecFromBytes creates a new vector of the given length from (length+7)/8 bytes
+// as written by VecToBytes, see ByteOrder for the layout. Padding bits must be 0.
+func CSRVecFromBytes(length int, b []byte, order BitOrder, byteOrder ByteOrder) SparseVector {
+	if length < 0 {
+		panic(fmt.Sprintf("length must be >= 0 found %v", length))
+	}
+	if expected := (length + 7) / 8; len(b) != expected {
+		panic(fmt.Sprintf("packed data length (%v) to length mismatch expected %v", len(b), expected))
+	}
+
+	indices := make([]int, 0)
+	for k := range b {
+		for x := b[byteOrder.byteIndex(k, len(b))]; x != 0; x &= x - 1 {
+			p := k*8 + bits.TrailingZeros8(x)
+			if p >= length {
+				panic(fmt.Sprintf("padding bit %v of the integer is set, it only has %v bits", p, length))
+			}
+			indices = append(indices, order.bit(p, length))
+		}
+	}
+	sort.Ints(indices)
+	return &CSRVector{length: length, indices: indices}
+}
+
+// VecToBytes returns the vector packed into (length+7)/8 bytes, see ByteOrder for
+// the layout.
+func VecToBytes(vec SparseVector, order BitOrder, byteOrder ByteOrder) []byte {
+	length := vec.Len()
+	b := make([]byte, (length+7)/8)
+	for _, i := range vec.NonzeroArray() {
+		p := order.bit(i, length)
+		b[byteOrder.byteIndex(p/8, len(b))] |= 1 << uint(p%8)
+	}
+	return b
+}
+
+// CSRVecFromWords creates a new vector of the given length from (length+63)/64
+// packed words as written by VecToWords. Padding bits in the last word must be 0.
+func CSRVecFromWords(length int, words []uint64, order BitOrder) SparseVector {
+	return csrVecFromPacked(length, 64, len(words), func(k int) uint64 { return words[k] }, order)
+}
+
+// VecToWords returns the vector packed into (length+63)/64 words, with LSBFirst
+// this is the row layout used by MatToWords.
+func VecToWords(vec SparseVector, order BitOrder) []uint64 {
+	words := make([]uint64, wordsPerRow(vec.Len()))
+	for _, i := range vec.NonzeroArray() {
+		words[i/64] |= 1 << uint(order.bit(i%64, 64))
+	}
+	return words
+}
+
+// csrVecFromPacked unpacks count fields of size bits each, returned by field,
+// into a vector of the given length.
+func csrVecFromPacked(length, size, count int, field func(k int) uint64, order BitOrder) SparseVector {
+	if length < 0 {
+		panic(fmt.Sprintf("length must be >= 0 found %v", length))
+	}
+	if expected := (length + size - 1) / size; count != expected {
+		panic(fmt.Sprintf("packed data length (%v) to length mismatch expected %v", count, expected))
+	}
+
+	indices := make([]int, 0)
+	for k := 0; k < count; k++ {
+		for x := field(k); x != 0; x &= x - 1 {
+			i := k*size + order.bit(bits.TrailingZeros64(x), size)
+			if i >= length {
+				panic(fmt.Sprintf("bit for index %v set past the last index %v", i, length-1))
+			}
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+	return &CSRVector{length: length, indices: indices}
+}
@@ -0,0 +1,172 @@
+package sparsemat
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestCSRVecFromUint64(t *testing.T) {
+	tests := []struct {
+		length   int
+		x        uint64
+		order    BitOrder
+		expected SparseVector
+	}{
+		{0, 0, LSBFirst, CSRVec(0)},
+		{4, 0x3, LSBFirst, CSRVec(4, 1, 1, 0, 0)},
+		{4, 0x3, MSBFirst, CSRVec(4, 0, 0, 1, 1)},
+		{5, 0x10, MSBFirst, CSRVec(5, 1, 0, 0, 0, 0)},
+		{64, 1 << 63, LSBFirst, CSRVecCopy(DOKVec(64).Set(63, 1))},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRVecFromUint64(test.length, test.x, test.order)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if x := VecToUint64(actual, test.order); x != test.x {
+				t.Fatalf("expected %#x but found %#x", test.x, x)
+			}
+		})
+	}
+}
+
+func TestCSRVecFromUint64Overflow(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic for a value past the length")
+		}
+	}()
+	CSRVecFromUint64(3, 0x8, LSBFirst)
+}
+
+func TestCSRVecFromBigInt(t *testing.T) {
+	tests := []struct {
+		length   int
+		x        *big.Int
+		order    BitOrder
+		expected SparseVector
+	}{
+		{3, big.NewInt(0), LSBFirst, CSRVec(3)},
+		{4, big.NewInt(0x3), LSBFirst, CSRVec(4, 1, 1, 0, 0)},
+		{4, big.NewInt(0x3), MSBFirst, CSRVec(4, 0, 0, 1, 1)},
+		{100, new(big.Int).Lsh(big.NewInt(1), 99), MSBFirst, CSRVecCopy(DOKVec(100).Set(0, 1))},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRVecFromBigInt(test.length, test.x, test.order)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if x := VecToBigInt(actual, test.order); x.Cmp(test.x) != 0 {
+				t.Fatalf("expected %v but found %v", test.x, x)
+			}
+		})
+	}
+}
+
+func TestCSRVecFromBytes(t *testing.T) {
+	tests := []struct {
+		length    int
+		b         []byte
+		order     BitOrder
+		byteOrder ByteOrder
+		expected  SparseVector
+	}{
+		{0, []byte{}, LSBFirst, LittleEndian, CSRVec(0)},
+		{10, []byte{0x01, 0x02}, LSBFirst, LittleEndian, CSRVec(10, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1)},
+		{10, []byte{0x02, 0x01}, LSBFirst, BigEndian, CSRVec(10, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1)},
+		{10, []byte{0x01, 0x01}, MSBFirst, LittleEndian, CSRVec(10, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1)},
+		{10, []byte{0x01, 0x01}, MSBFirst, BigEndian, CSRVec(10, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1)},
+		{16, []byte{0x12, 0x34}, MSBFirst, BigEndian, CSRVecFromUint64(16, 0x1234, MSBFirst)},
+		{16, []byte{0x34, 0x12}, LSBFirst, LittleEndian, CSRVecFromUint64(16, 0x1234, LSBFirst)},
+		{16, []byte{0x12, 0x34}, LSBFirst, BigEndian, CSRVecFromUint64(16, 0x1234, LSBFirst)},
+		{12, []byte{0x01, 0x23}, LSBFirst, BigEndian, CSRVecFromUint64(12, 0x123, LSBFirst)},
+		{12, []byte{0x01, 0x23}, MSBFirst, BigEndian, CSRVecFromUint64(12, 0x123, MSBFirst)},
+		{12, []byte{0x23, 0x01}, MSBFirst, LittleEndian, CSRVecFromUint64(12, 0x123, MSBFirst)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRVecFromBytes(test.length, test.b, test.order, test.byteOrder)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if b := VecToBytes(actual, test.order, test.byteOrder); !bytes.Equal(b, test.b) {
+				t.Fatalf("expected %x but found %x", test.b, b)
+			}
+		})
+	}
+}
+
+func TestCSRVecFromBytesPadding(t *testing.T) {
+	tests := []struct {
+		b         []byte
+		byteOrder ByteOrder
+	}{
+		{[]byte{0x10}, LittleEndian},
+		{[]byte{0x04, 0x00}, BigEndian},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic for a padding bit")
+				}
+			}()
+			CSRVecFromBytes(len(test.b)*8-6, test.b, MSBFirst, test.byteOrder)
+		})
+	}
+}
+
+func TestCSRVecFromWords(t *testing.T) {
+	tests := []struct {
+		length   int
+		words    []uint64
+		order    BitOrder
+		expected []int
+	}{
+		{0, []uint64{}, LSBFirst, []int{}},
+		{65, []uint64{0x2, 0x1}, LSBFirst, []int{1, 64}},
+		{65, []uint64{1 << 62, 1 << 63}, MSBFirst, []int{1, 64}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CSRVecFromWords(test.length, test.words, test.order)
+			if actual.Len() != test.length || !reflect.DeepEqual(actual.NonzeroArray(), test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual.NonzeroArray())
+			}
+			if words := VecToWords(actual, test.order); !reflect.DeepEqual(words, test.words) {
+				t.Fatalf("expected %x but found %x", test.words, words)
+			}
+		})
+	}
+}
+
+func TestVecConversionRoundTrip(t *testing.T) {
+	for i, size := range []int{1, 7, 8, 63, 64, 65, 200} {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			vec := randomCSRVector(size)
+			for _, order := range []BitOrder{LSBFirst, MSBFirst} {
+				for _, byteOrder := range []ByteOrder{LittleEndian, BigEndian} {
+					b := VecToBytes(vec, order, byteOrder)
+					if actual := CSRVecFromBytes(size, b, order, byteOrder); !actual.Equals(vec) {
+						t.Fatalf("bytes: expected %v but found %v", vec, actual)
+					}
+					x := VecToBigInt(vec, order)
+					if byteOrder == BigEndian && !bytes.Equal(x.FillBytes(make([]byte, len(b))), b) {
+						t.Fatalf("bytes: expected the big-endian encoding of %v but found %x", x, b)
+					}
+				}
+				if actual := CSRVecFromWords(size, VecToWords(vec, order), order); !actual.Equals(vec) {
+					t.Fatalf("words: expected %v but found %v", vec, actual)
+				}
+				if actual := CSRVecFromBigInt(size, VecToBigInt(vec, order), order); !actual.Equals(vec) {
+					t.Fatalf("big.Int: expected %v but found %v", vec, actual)
+				}
+			}
+		})
+	}
+}